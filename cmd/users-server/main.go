@@ -6,6 +6,7 @@ import (
 	"os"
 
 	grpc "github.com/maelvls/users-grpc/pkg/grpc"
+	service "github.com/maelvls/users-grpc/pkg/service"
 	"github.com/sirupsen/logrus"
 )
 
@@ -27,6 +28,12 @@ var (
 	// https://github.com/grpc/grpc-go/blob/master/Documentation/server-reflection-tutorial.md
 	reflection  = flag.Bool("reflection", true, "Enable reflection, useful for using grpcurl or related tools.")
 	addrMetrics = flag.String("address-metrics", ":9402", "Address used by the prometheus server to start listening.")
+	maxBatchGet = flag.Int("max-batch-get", grpc.DefaultMaxBatchGet, "Maximum number of emails and ids that can be given to BatchGet at once.")
+	argonMemory = flag.Uint("argon2-memory", uint(service.DefaultPasswordHasher.Memory), "Memory in KiB used for hashing each password with argon2id.")
+	argonTime   = flag.Uint("argon2-iterations", uint(service.DefaultPasswordHasher.Time), "Number of argon2id passes over the memory when hashing a password.")
+	maxFailures = flag.Int("max-failed-logins", service.DefaultLockout.MaxAttempts, "Number of consecutive failed authentications after which an account is locked; 0 disables the lockout.")
+	lockoutFor  = flag.Duration("lockout-duration", service.DefaultLockout.Duration, "How long an account stays locked after too many failed authentications.")
+	foldRules   = flag.String("fold-rules", "", "Extra rules applied to names and addresses before searching them, e.g. 'ü=ue,ö=oe' lets 'mueller' find 'Müller'.")
 )
 
 func main() {
@@ -47,9 +54,33 @@ func main() {
 		logrus.SetLevel(logrus.TraceLevel)
 	}
 
+	rules, err := service.ParseFoldRules(*foldRules)
+	if err != nil {
+		logrus.Errorf("--fold-rules: %v", err)
+		os.Exit(1)
+	}
+	service.SetFoldRules(rules)
+
+	if *maxBatchGet <= 0 {
+		logrus.Errorf("--max-batch-get must be positive")
+		os.Exit(1)
+	}
+
+	hasher := service.DefaultPasswordHasher
+	hasher.Memory, hasher.Time = uint32(*argonMemory), uint32(*argonTime)
+	if hasher.Time < 1 || hasher.Memory < 8*uint32(hasher.Threads) {
+		logrus.Errorf("--argon2-iterations must be positive and --argon2-memory must be at least %d", 8*hasher.Threads)
+		os.Exit(1)
+	}
+	if *maxFailures < 0 || *lockoutFor < 0 {
+		logrus.Errorf("--max-failed-logins and --lockout-duration cannot be negative")
+		os.Exit(1)
+	}
+	lockout := service.Lockout{MaxAttempts: *maxFailures, Duration: *lockoutFor}
+
 	logrus.Printf("listening on address %s, metrics on %s (version %s, git %s, built on %s)", *addr, *addrMetrics, version, commit, date)
 
-	if err := grpc.Run(context.Background(), *addr, *addrMetrics, *reflection, *tls, *samples, *certFile, *keyFile); err != nil {
+	if err := grpc.Run(context.Background(), *addr, *addrMetrics, *reflection, *tls, *samples, *certFile, *keyFile, *maxBatchGet, hasher, lockout); err != nil {
 		logrus.Errorf("running: %v", err)
 		os.Exit(1)
 	}
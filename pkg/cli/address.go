@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"github.com/maelvls/users-grpc/pkg/userfields"
+	pb "github.com/maelvls/users-grpc/schema/user"
+)
+
+// parseAddress turns a free-text address such as "255 Cortelyou Road,
+// Volta, Indiana, 1608" into its structured form; see userfields.ParseAddress.
+func parseAddress(s string) (*pb.Address, error) {
+	addr, err := userfields.ParseAddress(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return addressToPB(addr), nil
+}
+
+func addressToPB(addr userfields.Address) *pb.Address {
+	return &pb.Address{
+		Street:     addr.Street,
+		City:       addr.City,
+		Region:     addr.Region,
+		PostalCode: addr.PostalCode,
+		Country:    addr.Country,
+	}
+}
+
+// formatAddress is the inverse of parseAddress.
+func formatAddress(a *pb.Address) string {
+	return userfields.Address{
+		Street:     a.GetStreet(),
+		City:       a.GetCity(),
+		Region:     a.GetRegion(),
+		PostalCode: a.GetPostalCode(),
+		Country:    a.GetCountry(),
+	}.String()
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	changeEmailCmd := &cobra.Command{
+		Use:   "change-email OLD NEW [--if-match=ETAG]",
+		Short: "Change the email of a user; the user keeps its id",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("requires the old and the new email as arguments")
+			}
+			return nil
+		},
+		Run: func(changeEmailCmd *cobra.Command, args []string) {
+			oldEmail, newEmail := args[0], args[1]
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			etag, _ := changeEmailCmd.Flags().GetString("if-match")
+			resp, err := client.ChangeEmail(ctx, &pb.ChangeEmailReq{Email: oldEmail, NewEmail: newEmail, Etag: etag})
+			switch {
+			case err != nil:
+				logutil.Errorf("changing email: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() == pb.Status_CONFLICT:
+				exitWithConflict(resp.Status, oldEmail)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.Status.Msg)
+				os.Exit(1)
+			default:
+				// Happy path continuing below.
+			}
+
+			fmt.Println(Spprint(resp.User))
+		},
+	}
+
+	addIfMatchFlag(changeEmailCmd)
+
+	rootCmd.AddCommand(changeEmailCmd)
+}
@@ -15,7 +15,7 @@ import (
 
 func init() {
 	createCmd := &cobra.Command{
-		Use:   "create --email=EMAIL [--firstname] [--lastname] [--age] [--postaladdress]",
+		Use:   "create --email=EMAIL [--firstname] [--lastname] [--age] [--postaladdress] [--label=KEY=VALUE...] [--manager=EMAIL]",
 		Short: "Create a user",
 		Args: func(createCmd *cobra.Command, args []string) error {
 			email, err := createCmd.Flags().GetString("email")
@@ -39,6 +39,24 @@ func init() {
 			age, _ := createCmd.Flags().GetInt32("age")
 
 			postaladdress, _ := createCmd.Flags().GetString("postaladdress")
+			address, err := parseAddress(postaladdress)
+			if err != nil {
+				logutil.Errorf("--postaladdress: %v", err)
+				os.Exit(1)
+			}
+
+			labels, _, err := parseLabels(createCmd, false)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			managerID, err := parseManager(ctx, client, createCmd)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
 			email, _ := createCmd.Flags().GetString("email")
 
 			usr := &pb.User{
@@ -47,8 +65,10 @@ func init() {
 					First: firstname,
 					Last:  lastname,
 				},
-				Age:     age,
-				Address: postaladdress,
+				Age:       age,
+				Address:   address,
+				Labels:    labels,
+				ManagerId: managerID,
 			}
 
 			// Create the user.
@@ -71,6 +91,8 @@ func init() {
 	createCmd.Flags().String("email", "", "")     // brianna.shelton@email.org
 	createCmd.Flags().Int32("age", 0, "")
 	createCmd.Flags().String("postaladdress", "", "") // 255 Cortelyou Road, Volta, Indiana, 1608
+	addLabelFlag(createCmd, false)
+	addManagerFlag(createCmd, "The email of the user this user reports to")
 
 	rootCmd.AddCommand(createCmd)
 }
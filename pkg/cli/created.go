@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func addCreatedFlags(cmd *cobra.Command) {
+	cmd.Flags().String("created-after", "", "Only the users created after this time, e.g. '2020-06-01' or '2020-06-01T08:30:00+02:00'")
+	cmd.Flags().String("created-before", "", "Only the users created before this time, e.g. '2020-06-01' or '2020-06-01T08:30:00+02:00'")
+}
+
+// parseCreated returns the values of --created-after and --created-before.
+// A missing flag gives nil, which leaves the range open on that side.
+func parseCreated(cmd *cobra.Command) (after, before *timestamppb.Timestamp, err error) {
+	after, err = parseTimeFlag(cmd, "created-after")
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err = parseTimeFlag(cmd, "created-before")
+	if err != nil {
+		return nil, nil, err
+	}
+	return after, before, nil
+}
+
+// parseTimeFlag accepts either an RFC 3339 time or a date, which is then
+// the midnight of this day in UTC.
+func parseTimeFlag(cmd *cobra.Command, flag string) (*timestamppb.Timestamp, error) {
+	value, _ := cmd.Flags().GetString(flag)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("--%s: '%s' is neither a date such as '2020-06-01' nor a time such as '2020-06-01T08:30:00Z'", flag, value)
+	}
+
+	return timestamppb.New(t), nil
+}
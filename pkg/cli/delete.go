@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	deleteCmd := &cobra.Command{
+		Use:   "delete EMAIL [--yes] [--if-match=ETAG]",
+		Short: "Delete a user by its email (must be exact, not partial)",
+		Long: `Delete a user by its email. The user is only hidden and can be brought
+back with 'users-cli restore'; its email cannot be used by another user
+until it is removed for good with 'users-cli purge'.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires an email as argument")
+			}
+			return nil
+		},
+		Run: func(deleteCmd *cobra.Command, args []string) {
+			givenEmail := args[0]
+
+			// We only ask for confirmation when a human is watching;
+			// scripts that pipe the output do not need --yes.
+			yes, _ := deleteCmd.Flags().GetBool("yes")
+			if !yes && isatty.IsTerminal(os.Stdout.Fd()) && !confirm(fmt.Sprintf("Delete the user %s?", givenEmail)) {
+				logutil.Infof("aborted, nothing was deleted")
+				return
+			}
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			etag, _ := deleteCmd.Flags().GetString("if-match")
+			resp, err := client.Delete(ctx, &pb.DeleteReq{Email: givenEmail, Etag: etag})
+			switch {
+			case err != nil:
+				logutil.Errorf("deleting user: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() == pb.Status_CONFLICT:
+				exitWithConflict(resp.Status, givenEmail)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.Status.Msg)
+				os.Exit(1)
+			default:
+				// Happy path.
+			}
+		},
+	}
+
+	deleteCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+	addIfMatchFlag(deleteCmd)
+
+	rootCmd.AddCommand(deleteCmd)
+}
+
+// confirm prints the question and waits for the user to answer with 'y'
+// or 'yes' on stdin. Anything else is a no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
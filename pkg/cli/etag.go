@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+// addIfMatchFlag adds --if-match to the commands that write a user so that
+// two people editing the same user do not silently overwrite each other.
+func addIfMatchFlag(cmd *cobra.Command) {
+	cmd.Flags().String("if-match", "", "Only write if the user has not changed since this etag was shown by 'users-cli get --etag'")
+}
+
+// exitWithConflict is used when the server refused a write with the
+// CONFLICT status, i.e., when the user was modified since the etag given
+// to --if-match.
+func exitWithConflict(status *pb.Status, email string) {
+	logutil.Errorf("%s; someone else changed this user in the meantime, run 'users-cli get --etag %s' to see the latest version and try again with its etag", status.Msg, email)
+	os.Exit(1)
+}
@@ -14,16 +14,30 @@ import (
 
 func init() {
 	getCmd := &cobra.Command{
-		Use:   "get EMAIL",
-		Short: "Fetch a user by its email (must be exact, not partial)",
+		Use:   "get (EMAIL [--include-deleted] | --id=ID | --phone=PHONE) [--etag]",
+		Short: "Fetch a user by its email, by its id or by its phone (must be exact, not partial)",
+		Long: `Fetch a user by its email, by its id (--id) or by its phone (--phone). The
+email and the id must be exact. The phone can be formatted in any way, e.g. '906-568-2594'
+finds '+1 (906) 568-2594'; the phones without a country calling code are
+assumed to be from the US.
+
+The deleted users are not found unless --include-deleted is given, which
+only works with an email and is rejected with --id or --phone.`,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("requires an email as argument")
+			byID, byPhone := cmd.Flags().Changed("id"), cmd.Flags().Changed("phone")
+			switch {
+			case byID && byPhone:
+				return errors.New("cannot give both --id and --phone")
+			case (byID || byPhone) && len(args) > 0:
+				return errors.New("cannot give both an email and --id or --phone")
+			case (byID || byPhone) && cmd.Flags().Changed("include-deleted"):
+				return errors.New("--include-deleted only works with an email, not with --id or --phone")
+			case !byID && !byPhone && len(args) < 1:
+				return errors.New("requires an email as argument, or --id or --phone")
 			}
 			return nil
 		},
 		Run: func(getCmd *cobra.Command, args []string) {
-			givenEmail := args[0]
 
 			client, err := createClient(cfg)
 			if err != nil {
@@ -34,24 +48,59 @@ func init() {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
-			resp, err := client.GetByEmail(ctx, &user.GetByEmailReq{Email: givenEmail})
+			var usr *user.User
+			var status *user.Status
 			switch {
-			case err != nil:
-				logutil.Errorf("get by email: %v", err)
-				os.Exit(1)
-			case resp.GetStatus().GetCode() == user.Status_FAILED:
-				logutil.Errorf(resp.Status.Msg)
+			case getCmd.Flags().Changed("phone"):
+				phone, _ := getCmd.Flags().GetString("phone")
+				resp, err := client.GetByPhone(ctx, &user.GetByPhoneReq{Phone: phone})
+				if err != nil {
+					logutil.Errorf("get by phone: %v", err)
+					os.Exit(1)
+				}
+				usr, status = resp.GetUser(), resp.GetStatus()
+			case getCmd.Flags().Changed("id"):
+				id, _ := getCmd.Flags().GetString("id")
+				resp, err := client.GetByID(ctx, &user.GetByIDReq{Id: id})
+				if err != nil {
+					logutil.Errorf("get by id: %v", err)
+					os.Exit(1)
+				}
+				usr, status = resp.GetUser(), resp.GetStatus()
+			default:
+				includeDeleted, _ := getCmd.Flags().GetBool("include-deleted")
+				resp, err := client.GetByEmail(ctx, &user.GetByEmailReq{Email: args[0], IncludeDeleted: includeDeleted})
+				if err != nil {
+					logutil.Errorf("get by email: %v", err)
+					os.Exit(1)
+				}
+				usr, status = resp.GetUser(), resp.GetStatus()
+			}
+
+			switch {
+			case status.GetCode() == user.Status_FAILED, status.GetCode() == user.Status_INVALID_QUERY:
+				logutil.Errorf(status.Msg)
 				os.Exit(1)
-			case resp.GetStatus().GetCode() != user.Status_SUCCESS:
-				logutil.Errorf("%#+v", resp.GetStatus())
+			case status.GetCode() != user.Status_SUCCESS:
+				logutil.Errorf("%#+v", status)
 				os.Exit(1)
 			default:
 				// Happy path continuing below.
 			}
 
 			// Finally, let's display the found user.
-			fmt.Println(Spprint(resp.User))
+			if showEtag, _ := getCmd.Flags().GetBool("etag"); showEtag {
+				fmt.Printf("%s [etag: %s]\n", Spprint(usr), usr.Etag)
+				return
+			}
+			fmt.Println(Spprint(usr))
 		},
 	}
+	getCmd.Flags().String("id", "", "Fetch the user with this id instead")
+	getCmd.Flags().String("phone", "", "Fetch the user with this phone number instead, e.g. '+1 (906) 568-2594' or '906-568-2594'")
+
+	getCmd.Flags().Bool("etag", false, "Also show the etag of the user, which can be given to --if-match when updating or deleting it")
+	getCmd.Flags().Bool("include-deleted", false, "Also find the user if it was deleted but not purged; only works with an email")
+
 	rootCmd.AddCommand(getCmd)
 }
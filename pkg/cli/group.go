@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	groupCmd := &cobra.Command{
+		Use:   "group",
+		Short: "Manage the groups of users, such as teams",
+		Long: `Manage the groups of users. The groups are found by their names, which
+are case-insensitive, and the members by their emails. Deleted users are
+removed from their groups.`,
+	}
+
+	groupCreateCmd := &cobra.Command{
+		Use:   "create NAME [--description=TEXT]",
+		Short: "Create a group without members",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires a group name as argument")
+			}
+			return nil
+		},
+		Run: func(groupCreateCmd *cobra.Command, args []string) {
+			client, err := createGroupClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			description, _ := groupCreateCmd.Flags().GetString("description")
+			resp, err := client.CreateGroup(ctx, &pb.CreateGroupReq{Name: args[0], Description: description})
+			switch {
+			case err != nil:
+				logutil.Errorf("creating group: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.GetStatus().GetMsg())
+				os.Exit(1)
+			default:
+				// Happy path.
+			}
+		},
+	}
+	groupCreateCmd.Flags().String("description", "", "What the group is about, e.g. 'The payments team'")
+
+	groupDeleteCmd := &cobra.Command{
+		Use:   "delete NAME [--yes]",
+		Short: "Delete a group; its members are not deleted",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires a group name as argument")
+			}
+			return nil
+		},
+		Run: func(groupDeleteCmd *cobra.Command, args []string) {
+			// Same as for 'users-cli delete'.
+			yes, _ := groupDeleteCmd.Flags().GetBool("yes")
+			if !yes && isatty.IsTerminal(os.Stdout.Fd()) && !confirm(fmt.Sprintf("Delete the group %s?", args[0])) {
+				logutil.Infof("aborted, nothing was deleted")
+				return
+			}
+
+			client, err := createGroupClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.DeleteGroup(ctx, &pb.DeleteGroupReq{Name: args[0]})
+			switch {
+			case err != nil:
+				logutil.Errorf("deleting group: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.GetStatus().GetMsg())
+				os.Exit(1)
+			default:
+				// Happy path.
+			}
+		},
+	}
+	groupDeleteCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+
+	groupListCmd := &cobra.Command{
+		Use:   "list [--member=EMAIL]",
+		Short: "List the groups sorted by name",
+		Args:  cobra.NoArgs,
+		Run: func(groupListCmd *cobra.Command, args []string) {
+			client, err := createGroupClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			var groups []*pb.Group
+			var status *pb.Status
+			if member, _ := groupListCmd.Flags().GetString("member"); member != "" {
+				resp, err := client.ListGroupsOfUser(ctx, &pb.ListGroupsOfUserReq{Email: member})
+				groups, status = resp.GetGroups(), resp.GetStatus()
+				if err != nil {
+					logutil.Errorf("listing the groups of %s: %v", member, err)
+					os.Exit(1)
+				}
+			} else {
+				resp, err := client.ListGroups(ctx, &pb.ListGroupsReq{})
+				groups, status = resp.GetGroups(), resp.GetStatus()
+				if err != nil {
+					logutil.Errorf("listing groups: %v", err)
+					os.Exit(1)
+				}
+			}
+			if status.GetCode() != pb.Status_SUCCESS {
+				logutil.Errorf(status.GetMsg())
+				os.Exit(1)
+			}
+
+			for _, g := range groups {
+				fmt.Println(SpprintGroup(g))
+			}
+		},
+	}
+	groupListCmd.Flags().String("member", "", "Only list the groups of the user that has this email")
+
+	groupCmd.AddCommand(groupCreateCmd, groupDeleteCmd, groupListCmd)
+	addGroupMembersCmds(groupCmd)
+
+	rootCmd.AddCommand(groupCmd)
+}
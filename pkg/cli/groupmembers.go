@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+// addGroupMembersCmds adds the subcommands of 'users-cli group' that
+// change or list the members of a group.
+func addGroupMembersCmds(groupCmd *cobra.Command) {
+	nameAndEmails := func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("requires a group name and at least one email as arguments")
+		}
+		return nil
+	}
+
+	groupAddCmd := &cobra.Command{
+		Use:   "add NAME EMAIL...",
+		Short: "Add users to a group",
+		Args:  nameAndEmails,
+		Run: func(groupAddCmd *cobra.Command, args []string) {
+			client, err := createGroupClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.AddMembers(ctx, &pb.AddMembersReq{Name: args[0], Emails: args[1:]})
+			if err != nil {
+				logutil.Errorf("adding members: %v", err)
+				os.Exit(1)
+			}
+			exitOnMemberFailures(resp.GetStatus(), resp.GetFailures())
+		},
+	}
+
+	groupRemoveCmd := &cobra.Command{
+		Use:   "remove NAME EMAIL...",
+		Short: "Remove users from a group",
+		Args:  nameAndEmails,
+		Run: func(groupRemoveCmd *cobra.Command, args []string) {
+			client, err := createGroupClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.RemoveMembers(ctx, &pb.RemoveMembersReq{Name: args[0], Emails: args[1:]})
+			if err != nil {
+				logutil.Errorf("removing members: %v", err)
+				os.Exit(1)
+			}
+			exitOnMemberFailures(resp.GetStatus(), resp.GetFailures())
+		},
+	}
+
+	groupMembersCmd := &cobra.Command{
+		Use:   "members NAME",
+		Short: "List the members of a group sorted by email",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires a group name as argument")
+			}
+			return nil
+		},
+		Run: func(groupMembersCmd *cobra.Command, args []string) {
+			client, err := createGroupClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.ListMembers(ctx, &pb.ListMembersReq{Name: args[0]})
+			switch {
+			case err != nil:
+				logutil.Errorf("listing members: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.GetStatus().GetMsg())
+				os.Exit(1)
+			}
+
+			for _, u := range resp.Users {
+				fmt.Println(Spprint(u))
+			}
+		},
+	}
+
+	groupCmd.AddCommand(groupAddCmd, groupRemoveCmd, groupMembersCmd)
+}
+
+// exitOnMemberFailures prints the users that could not be added or
+// removed and exits unless all of them were.
+func exitOnMemberFailures(status *pb.Status, failures []*pb.MemberFailure) {
+	for _, failure := range failures {
+		logutil.Errorf("%s: %s", failure.Email, failure.GetStatus().GetMsg())
+	}
+	if status.GetCode() != pb.Status_SUCCESS {
+		logutil.Errorf(status.GetMsg())
+		os.Exit(1)
+	}
+}
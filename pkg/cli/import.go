@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	"github.com/maelvls/users-grpc/pkg/userfields"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	importCmd := &cobra.Command{
+		Use:   "import FILE [--batch-size=N]",
+		Short: "Create the users contained in a JSON file ('-' for stdin)",
+		Long: `Create the users contained in a JSON file. The file contains an array of
+users written the same way as the sample users, e.g.
+
+    [{"email": "brianna.shelton@email.org", "firstName": "Brianna",
+      "lastName": "Shelton", "age": 23,
+      "address": "255 Cortelyou Road, Volta, Indiana, 1608"}]
+
+The users are committed by batches (100 users by default). The users that
+cannot be created, e.g. because their email is already used, are printed
+and do not prevent the other users from being created. The command exits
+with 1 when at least one user was rejected.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires a file as argument")
+			}
+			return nil
+		},
+		Run: func(importCmd *cobra.Command, args []string) {
+			var f io.Reader = os.Stdin
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					logutil.Errorf("%v", err)
+					os.Exit(1)
+				}
+				defer file.Close()
+				f = file
+			}
+
+			batchSize, _ := importCmd.Flags().GetInt32("batch-size")
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			// No timeout since the file can be arbitrarily large.
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := client.BulkCreate(ctx)
+			if err != nil {
+				logutil.Errorf("importing users: %v", err)
+				os.Exit(1)
+			}
+
+			count := 0
+			err = readUsers(f, func(u *pb.User) error {
+				req := &pb.BulkCreateReq{User: u}
+				if count == 0 {
+					req.BatchSize = batchSize
+				}
+				count++
+				return stream.Send(req)
+			})
+			// When the server closes the stream early, Send returns io.EOF
+			// and the reason is given by CloseAndRecv.
+			if err != nil && err != io.EOF {
+				logutil.Errorf("importing users: %v", err)
+				os.Exit(1)
+			}
+
+			resp, err := stream.CloseAndRecv()
+			if err != nil {
+				logutil.Errorf("importing users: %v", err)
+				os.Exit(1)
+			}
+
+			for _, failure := range resp.Failures {
+				logutil.Errorf("user #%d (%s): %s", failure.Index+1, failure.Email, failure.Status.GetMsg())
+			}
+
+			switch resp.GetStatus().GetCode() {
+			case pb.Status_SUCCESS:
+				logutil.Infof("%d users created", resp.Created)
+			case pb.Status_PARTIAL_SUCCESS:
+				logutil.Errorf(resp.Status.Msg)
+				os.Exit(1)
+			default:
+				logutil.Errorf(resp.GetStatus().GetMsg())
+				os.Exit(1)
+			}
+		},
+	}
+
+	importCmd.Flags().Int32("batch-size", 0, "Number of users committed at once (default 100)")
+
+	rootCmd.AddCommand(importCmd)
+}
+
+// readUsers decodes the JSON array of users one user at a time so that
+// large files do not have to be loaded in memory.
+func readUsers(r io.Reader, fn func(*pb.User) error) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("reading the file: %w", err)
+	}
+	if tok != json.Delim('[') {
+		return errors.New("the file must contain a JSON array")
+	}
+
+	for i := 1; dec.More(); i++ {
+		var u importedUser
+		if err := dec.Decode(&u); err != nil {
+			return fmt.Errorf("reading the user #%d: %w", i, err)
+		}
+		if err := fn(userToPB(u)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importedUser is a user as written in the file to import. Only the fields
+// that can be given when creating a user are kept; the others, such as
+// createdAt, are ignored.
+type importedUser struct {
+	ID        string             `json:"id"`
+	Age       int32              `json:"age"`
+	FirstName string             `json:"firstName"`
+	LastName  string             `json:"lastName"`
+	Email     string             `json:"email"`
+	Phone     string             `json:"phone"`
+	Address   userfields.Address `json:"address"`
+	Labels    map[string]string  `json:"labels"`
+	ManagerID string             `json:"managerId"`
+}
+
+func userToPB(u importedUser) *pb.User {
+	return &pb.User{
+		Id:    u.ID,
+		Email: u.Email,
+		Phone: u.Phone,
+		Name: &pb.Name{
+			First: u.FirstName,
+			Last:  u.LastName,
+		},
+		Age:       u.Age,
+		Address:   addressToPB(u.Address),
+		Labels:    u.Labels,
+		ManagerId: u.ManagerID,
+	}
+}
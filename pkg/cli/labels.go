@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maelvls/users-grpc/pkg/userfields"
+	"github.com/spf13/cobra"
+)
+
+// addLabelFlag adds --label, which can be repeated. When removable is
+// true, "--label=KEY-" removes the label KEY.
+func addLabelFlag(cmd *cobra.Command, removable bool) {
+	usage := "Set a label of the form KEY=VALUE, e.g. 'team=payments'; can be repeated"
+	if removable {
+		usage += "; KEY- removes the label"
+	}
+	cmd.Flags().StringArray("label", nil, usage)
+}
+
+// parseLabels reads --label. The labels to remove are only returned when
+// the flag was added with removable.
+func parseLabels(cmd *cobra.Command, removable bool) (set map[string]string, remove []string, _ error) {
+	given, _ := cmd.Flags().GetStringArray("label")
+	set = make(map[string]string)
+	for _, label := range given {
+		if key := strings.TrimSuffix(label, "-"); removable && key != label && !strings.Contains(label, "=") {
+			remove = append(remove, key)
+			continue
+		}
+
+		labels, err := userfields.ParseLabels(label)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--label: %w", err)
+		}
+		for k, v := range labels {
+			set[k] = v
+		}
+	}
+	return set, remove, nil
+}
+
+func sortedKeys(labels map[string]string) []string {
+	var keys []string
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addSelectorFlag adds --selector, which selects users by their labels.
+func addSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("selector", "l", "", "Only the users whose labels match this selector, e.g. 'team=payments,plan in (pro,team),!deprecated'; the operators are =, !=, in, notin and KEY or !KEY for checking whether the label exists")
+}
+
+// formatLabels returns e.g. "plan=pro,team=payments".
+func formatLabels(labels map[string]string) string {
+	var res []string
+	for _, k := range sortedKeys(labels) {
+		res = append(res, k+"="+labels[k])
+	}
+	return strings.Join(res, ",")
+}
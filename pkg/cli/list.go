@@ -3,19 +3,20 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"time"
 
 	"github.com/maelvls/users-grpc/pkg/cli/logutil"
 	"github.com/maelvls/users-grpc/schema/user"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	listCmd := &cobra.Command{
-		Use:   "list",
+		Use:   "list [--include-deleted] [--selector=SELECTOR] [--created-after=TIME] [--created-before=TIME] [--sort=FIELDS] [--page-size=N [--page-token=TOKEN | --all] | --stream]",
 		Short: "List all users",
+		Long: `List all users. With --stream, the users are printed as they are
+received; --sort and the paging flags cannot be used with --stream.`,
 		Run: func(listCmd *cobra.Command, args []string) {
 			client, err := createClient(cfg)
 			if err != nil {
@@ -23,30 +24,78 @@ func init() {
 				os.Exit(1)
 			}
 
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-
-			resp, err := client.List(ctx, &user.ListReq{})
-			switch {
-			case err != nil:
-				logutil.Errorf("listing users: %v", err)
-				os.Exit(1)
-			case resp.GetStatus().GetCode() != user.Status_SUCCESS:
-				logutil.Errorf("%v", resp.GetStatus())
+			includeDeleted, _ := listCmd.Flags().GetBool("include-deleted")
+			createdAfter, createdBefore, err := parseCreated(listCmd)
+			if err != nil {
+				logutil.Errorf("%v", err)
 				os.Exit(1)
-			default:
-				// Happy path continuing below.
 			}
 
-			logrus.Debugf("number of users received: %v", len(resp.GetUsers()))
+			selector, _ := listCmd.Flags().GetString("selector")
 
-			// Finally, we can display the users.
-			for _, user := range resp.GetUsers() {
-				fmt.Println(Spprint(user))
+			if stream, _ := listCmd.Flags().GetBool("stream"); stream {
+				for _, flag := range []string{"sort", "page-size", "page-token", "all"} {
+					if listCmd.Flags().Changed(flag) {
+						logutil.Errorf("--%s cannot be used with --stream", flag)
+						os.Exit(1)
+					}
+				}
+				err = streamList(client, &user.StreamListReq{IncludeDeleted: includeDeleted, CreatedAfter: createdAfter, CreatedBefore: createdBefore, Selector: selector})
+				if err != nil {
+					logutil.Errorf("listing users: %v", err)
+					os.Exit(1)
+				}
+				return
 			}
 
-			cancel()
+			orderBy, err := parseSort(listCmd)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			err = printPages(listCmd, func(ctx context.Context, pageSize int32, pageToken string) (*user.SearchResp, error) {
+				return client.List(ctx, &user.ListReq{PageSize: pageSize, PageToken: pageToken, OrderBy: orderBy, IncludeDeleted: includeDeleted, CreatedAfter: createdAfter, CreatedBefore: createdBefore, Selector: selector})
+			})
+			if err != nil {
+				logutil.Errorf("listing users: %v", err)
+				os.Exit(1)
+			}
 		},
 	}
 
+	addPagingFlags(listCmd)
+	addSortFlag(listCmd)
+	addCreatedFlags(listCmd)
+	addSelectorFlag(listCmd)
+	listCmd.Flags().Bool("include-deleted", false, "Also list the users that were deleted but not purged")
+	listCmd.Flags().Bool("stream", false, "Print the users as they are received instead of waiting for the whole list")
+
 	rootCmd.AddCommand(listCmd)
 }
+
+// streamList prints the users as soon as they arrive. There is no timeout
+// since listing a large number of users may take a while.
+func streamList(client user.UserServiceClient, req *user.StreamListReq) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamList(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		case resp.GetStatus() != nil:
+			return fmt.Errorf("%s: %s", resp.Status.Code, resp.Status.Msg)
+		default:
+			fmt.Println(Spprint(resp.GetUser()))
+		}
+	}
+}
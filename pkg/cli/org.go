@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	orgCmd := &cobra.Command{
+		Use:   "org EMAIL [--depth=N] [--managers]",
+		Short: "Print the users that report to a user as a tree",
+		Long: `Print the user along with the users that report to it, directly or
+not, as a tree. With --managers, the managers of the user are printed
+instead, from its direct manager up to the user that has no manager.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires an email as argument")
+			}
+			return nil
+		},
+		Run: func(orgCmd *cobra.Command, args []string) {
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if managers, _ := orgCmd.Flags().GetBool("managers"); managers {
+				resp, err := client.GetReportingChain(ctx, &pb.GetReportingChainReq{Email: args[0]})
+				switch {
+				case err != nil:
+					logutil.Errorf("getting the managers: %v", err)
+					os.Exit(1)
+				case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+					logutil.Errorf(resp.GetStatus().GetMsg())
+					os.Exit(1)
+				}
+				for _, u := range resp.Users {
+					fmt.Println(Spprint(u))
+				}
+				return
+			}
+
+			depth, _ := orgCmd.Flags().GetInt32("depth")
+			resp, err := client.GetOrgTree(ctx, &pb.GetOrgTreeReq{Email: args[0], MaxDepth: depth})
+			switch {
+			case err != nil:
+				logutil.Errorf("getting the org tree: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.GetStatus().GetMsg())
+				os.Exit(1)
+			}
+
+			printOrgTree(resp.Root, "", "")
+		},
+	}
+
+	orgCmd.Flags().Int32("depth", 0, "Only print this number of levels below the user; 0 means no limit")
+	orgCmd.Flags().Bool("managers", false, "Print the managers of the user instead of its reports")
+
+	rootCmd.AddCommand(orgCmd)
+}
+
+// printOrgTree prints a node and its reports the same way as the 'tree'
+// command does, e.g.
+//
+//	Brianna Shelton <brianna.shelton@email.org>
+//	├── Rice Pierce <rice.pierce@email.com>
+//	│   └── ...
+//	└── Wilkerson Mosley <wilkerson.mosley@email.biz>
+//
+// The prefix is printed before the user and indent before its reports.
+func printOrgTree(node *pb.OrgNode, prefix, indent string) {
+	fmt.Printf("%s%s %s <%s>\n", prefix,
+		ansi.Color(node.User.GetName().GetFirst(), "yellow+b"),
+		ansi.Color(node.User.GetName().GetLast(), "yellow+b"),
+		ansi.Color(node.User.GetEmail(), "green"))
+
+	if node.Truncated {
+		fmt.Printf("%s└── ...\n", indent)
+	}
+	for i, report := range node.Reports {
+		if i == len(node.Reports)-1 {
+			printOrgTree(report, indent+"└── ", indent+"    ")
+		} else {
+			printOrgTree(report, indent+"├── ", indent+"│   ")
+		}
+	}
+}
+
+// addManagerFlag adds --manager, which takes the email of the manager
+// rather than its id.
+func addManagerFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().String("manager", "", usage)
+}
+
+// parseManager returns the id of the user whose email was given with
+// --manager, or an empty id when the flag is empty.
+func parseManager(ctx context.Context, client pb.UserServiceClient, cmd *cobra.Command) (string, error) {
+	email, _ := cmd.Flags().GetString("manager")
+	if email == "" {
+		return "", nil
+	}
+
+	resp, err := client.GetByEmail(ctx, &pb.GetByEmailReq{Email: email})
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("--manager: finding %s: %w", email, err)
+	case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+		return "", fmt.Errorf("--manager: %s", resp.GetStatus().GetMsg())
+	}
+
+	return resp.User.Id, nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+// When --all is given without --page-size, we still fetch the users in
+// pages of this size.
+const defaultPageSize = 100
+
+// Each page gets its own timeout so that --all works however many pages
+// there are.
+const pageTimeout = 10 * time.Second
+
+func addPagingFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32("page-size", 0, "Maximum number of users to fetch; 0 means all users at once")
+	cmd.Flags().String("page-token", "", "Token returned by a previous call for fetching the next page")
+	cmd.Flags().Bool("all", false, "Fetch all the pages one after the other")
+}
+
+// printPages calls fetch for each page and prints the users as pages come
+// in. Unless --all is given, only one page is fetched and the token for
+// the next page is shown on stderr. The context given to fetch expires
+// after pageTimeout.
+func printPages(cmd *cobra.Command, fetch func(ctx context.Context, pageSize int32, pageToken string) (*pb.SearchResp, error)) error {
+	pageSize, err := cmd.Flags().GetInt32("page-size")
+	if err != nil {
+		return fmt.Errorf("--page-size is not a number")
+	}
+	if pageSize < 0 {
+		return fmt.Errorf("--page-size cannot be negative")
+	}
+	pageToken, _ := cmd.Flags().GetString("page-token")
+	all, _ := cmd.Flags().GetBool("all")
+	if all && pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), pageTimeout)
+		resp, err := fetch(ctx, pageSize, pageToken)
+		cancel()
+		switch {
+		case err != nil:
+			return err
+		case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+			return fmt.Errorf("%s: %s", resp.Status.Code, resp.Status.Msg)
+		default:
+			// Happy path continuing below.
+		}
+
+		logutil.Debugf("number of users received: %v", len(resp.GetUsers()))
+		scores := resp.GetScores()
+		for i, u := range resp.GetUsers() {
+			if i < len(scores) {
+				fmt.Printf("%s [score: %.2f]\n", Spprint(u), scores[i])
+				continue
+			}
+			fmt.Println(Spprint(u))
+		}
+
+		pageToken = resp.GetNextPageToken()
+		switch {
+		case pageToken == "":
+			return nil
+		case !all:
+			logutil.Infof("more users are available, use --page-token=%s or --all to fetch them", pageToken)
+			return nil
+		}
+	}
+}
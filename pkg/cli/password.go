@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+func init() {
+	setPasswordCmd := &cobra.Command{
+		Use:   "set-password EMAIL",
+		Short: "Set or replace the password of a user",
+		Long: `Set or replace the password of a user. The password is prompted for
+when stdin is a terminal; otherwise, it is read from the first line of
+stdin, e.g.
+
+    echo 'correct horse' | users-cli set-password brianna.shelton@email.org
+
+The password must be at least 8 characters long. Setting a password also
+unlocks the account.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires an email as argument")
+			}
+			return nil
+		},
+		Run: func(setPasswordCmd *cobra.Command, args []string) {
+			password, err := readPassword("New password: ")
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			// Hashing the password takes a while on purpose.
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			resp, err := client.SetPassword(ctx, &pb.SetPasswordReq{Email: args[0], Password: password})
+			switch {
+			case err != nil:
+				logutil.Errorf("setting the password: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.GetStatus().GetMsg())
+				os.Exit(1)
+			}
+		},
+	}
+
+	authenticateCmd := &cobra.Command{
+		Use:   "authenticate EMAIL",
+		Short: "Check the password of a user and print the user when it matches",
+		Long: `Check the password of a user. The password is read the same way as
+with 'users-cli set-password'. The command exits with 1 when the password
+does not match or when the account is locked after too many failed
+attempts; both fail the same way.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires an email as argument")
+			}
+			return nil
+		},
+		Run: func(authenticateCmd *cobra.Command, args []string) {
+			password, err := readPassword("Password: ")
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			resp, err := client.Authenticate(ctx, &pb.AuthenticateReq{Email: args[0], Password: password})
+			switch {
+			case err != nil:
+				logutil.Errorf("authenticating: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.GetStatus().GetMsg())
+				os.Exit(1)
+			}
+
+			fmt.Println(Spprint(resp.User))
+		},
+	}
+
+	rootCmd.AddCommand(setPasswordCmd)
+	rootCmd.AddCommand(authenticateCmd)
+}
+
+// readPassword prompts for a password without echoing it when stdin is a
+// terminal, and reads the first line of stdin otherwise.
+func readPassword(prompt string) (string, error) {
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprint(os.Stderr, prompt)
+		password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("reading the password: %w", err)
+		}
+		return string(password), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("reading the password from stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -12,10 +12,27 @@ func Spprint(u *pb.User) string {
 	yel := ansi.ColorFunc("yellow+b")
 	gre := ansi.ColorFunc("green")
 	ansi.Color(u.Name.First, ansi.Yellow)
-	return fmt.Sprintf("%s %s <%s> (%v years old, address: %s)",
+	s := fmt.Sprintf("%s %s <%s> (%v years old, address: %s)",
 		yel(u.Name.First),
 		yel(u.Name.Last),
 		gre(u.Email),
 		u.Age,
-		u.Address)
+		formatAddress(u.Address))
+	if len(u.Labels) > 0 {
+		s += " [labels: " + formatLabels(u.Labels) + "]"
+	}
+	if u.DeletedAt != nil {
+		s += " " + ansi.Color("[deleted]", "red")
+	}
+	return s
+}
+
+// SpprintGroup displays a group, e.g. "payments (2 members): The payments
+// team".
+func SpprintGroup(g *pb.Group) string {
+	s := fmt.Sprintf("%s (%d members)", ansi.Color(g.Name, "yellow+b"), g.MemberCount)
+	if g.Description != "" {
+		s += ": " + g.Description
+	}
+	return s
 }
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	purgeCmd := &cobra.Command{
+		Use:   "purge EMAIL [--yes] [--if-match=ETAG]",
+		Short: "Remove for good a deleted user by its email (must be exact, not partial)",
+		Long: `Remove for good a user that was deleted with 'users-cli delete'. Once
+purged, the user cannot be restored anymore and its email can be used
+again.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires an email as argument")
+			}
+			return nil
+		},
+		Run: func(purgeCmd *cobra.Command, args []string) {
+			givenEmail := args[0]
+
+			yes, _ := purgeCmd.Flags().GetBool("yes")
+			if !yes && isatty.IsTerminal(os.Stdout.Fd()) && !confirm(fmt.Sprintf("Purge the user %s? It cannot be restored afterwards.", givenEmail)) {
+				logutil.Infof("aborted, nothing was purged")
+				return
+			}
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			etag, _ := purgeCmd.Flags().GetString("if-match")
+			resp, err := client.Purge(ctx, &pb.PurgeReq{Email: givenEmail, Etag: etag})
+			switch {
+			case err != nil:
+				logutil.Errorf("purging user: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() == pb.Status_CONFLICT:
+				exitWithConflict(resp.Status, givenEmail)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.Status.Msg)
+				os.Exit(1)
+			default:
+				// Happy path.
+			}
+		},
+	}
+
+	purgeCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+	addIfMatchFlag(purgeCmd)
+
+	rootCmd.AddCommand(purgeCmd)
+}
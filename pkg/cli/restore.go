@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	restoreCmd := &cobra.Command{
+		Use:   "restore EMAIL [--if-match=ETAG]",
+		Short: "Restore a deleted user by its email (must be exact, not partial)",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires an email as argument")
+			}
+			return nil
+		},
+		Run: func(restoreCmd *cobra.Command, args []string) {
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			etag, _ := restoreCmd.Flags().GetString("if-match")
+			resp, err := client.Restore(ctx, &pb.RestoreReq{Email: args[0], Etag: etag})
+			switch {
+			case err != nil:
+				logutil.Errorf("restoring user: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() == pb.Status_CONFLICT:
+				exitWithConflict(resp.Status, args[0])
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.Status.Msg)
+				os.Exit(1)
+			default:
+				// Happy path.
+			}
+		},
+	}
+
+	addIfMatchFlag(restoreCmd)
+
+	rootCmd.AddCommand(restoreCmd)
+}
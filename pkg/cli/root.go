@@ -26,7 +26,7 @@ var cfg clientCfg
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "users-cli (list | search | create | get)",
+	Use:   "users-cli (list | search | create | get | update | delete | change-email)",
 	Short: "A nice CLI for querying users from the user-grpc microservice.",
 
 	// https://github.com/spf13/cobra#prerun-and-postrun-hooks
@@ -137,6 +137,24 @@ func initConfig() {
 }
 
 func createClient(config clientCfg) (user.UserServiceClient, error) {
+	cc, err := dial(config)
+	if err != nil {
+		return nil, err
+	}
+	return user.NewUserServiceClient(cc), nil
+}
+
+// createGroupClient is the same as createClient for the group service,
+// which is served on the same address.
+func createGroupClient(config clientCfg) (user.GroupServiceClient, error) {
+	cc, err := dial(config)
+	if err != nil {
+		return nil, err
+	}
+	return user.NewGroupServiceClient(cc), nil
+}
+
+func dial(config clientCfg) (*grpc.ClientConn, error) {
 	var err error
 
 	split := strings.SplitN(config.address, ":", 2)
@@ -173,7 +191,5 @@ func createClient(config clientCfg) (user.UserServiceClient, error) {
 		os.Exit(1)
 	}
 
-	client := user.NewUserServiceClient(cc)
-
-	return client, err
+	return cc, err
 }
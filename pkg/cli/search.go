@@ -4,17 +4,31 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
+	"strings"
 
 	"github.com/maelvls/users-grpc/pkg/cli/logutil"
 	pb "github.com/maelvls/users-grpc/schema/user"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func init() {
 	searchCmd := &cobra.Command{
-		Use:   "search (--name=PARTIALNAME | --agefrom=N --ageto=M)",
+		Use:   "search [--name=PARTIALNAME] [--agefrom=N] [--ageto=M] [--email-domain=DOMAIN] [--phone=DIGITS] [--postaladdress=PARTIALADDRESS] [--city=CITY] [--region=REGION] [--selector=SELECTOR] [--created-after=TIME] [--created-before=TIME] [--include-deleted] [--match=MODE | --fuzzy] [--max-distance=N] [--sort=FIELDS] [--page-size=N [--page-token=TOKEN | --all]]",
 		Short: "Search users from the remote users-server",
+		Long: `Search users from the remote users-server. The users must match all the
+given criteria. The age range is open-ended: --agefrom alone returns the
+users that are at least that old, and --ageto alone returns the users that
+are at most that old. Unlike --postaladdress, --city and --region must
+be exact, although the case and the diacritics are ignored.
+
+With --created-after or --created-before, the users are sorted by creation
+time, unless --agefrom or --ageto is given.
+
+With --match=fuzzy (or --fuzzy), the names that are a few typos away from
+--name also match; the users are then ranked by score, which is shown next
+to each user. With --match=phonetic, the names that sound like --name
+match.`,
 		Run: func(searchCmd *cobra.Command, args []string) {
 			client, err := createClient(cfg)
 			if err != nil {
@@ -22,75 +36,135 @@ func init() {
 				os.Exit(1)
 			}
 
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
+			req := &pb.SearchReq{}
+			req.Name, _ = searchCmd.Flags().GetString("name")
+			req.EmailDomain, _ = searchCmd.Flags().GetString("email-domain")
+			req.Phone, _ = searchCmd.Flags().GetString("phone")
+			req.Address, _ = searchCmd.Flags().GetString("postaladdress")
+			req.City, _ = searchCmd.Flags().GetString("city")
+			req.Region, _ = searchCmd.Flags().GetString("region")
+			req.IncludeDeleted, _ = searchCmd.Flags().GetBool("include-deleted")
+			req.Selector, _ = searchCmd.Flags().GetString("selector")
+			req.CreatedAfter, req.CreatedBefore, err = parseCreated(searchCmd)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			if searchCmd.Flags().Changed("agefrom") {
+				ageFrom, err := searchCmd.Flags().GetInt32("agefrom")
+				if err != nil {
+					logutil.Errorf("--agefrom is not a number")
+					os.Exit(1)
+				}
+				req.AgeFrom = wrapperspb.Int32(ageFrom)
+			}
+			if searchCmd.Flags().Changed("ageto") {
+				ageTo, err := searchCmd.Flags().GetInt32("ageto")
+				if err != nil {
+					logutil.Errorf("--ageto is not a number")
+					os.Exit(1)
+				}
+				req.AgeToIncluded = wrapperspb.Int32(ageTo)
+			}
 
-			var searchAge, searchName bool
-			name, _ := searchCmd.Flags().GetString("name")
-			if name != "" {
-				searchName = true
+			if req.Name == "" && req.AgeFrom == nil && req.AgeToIncluded == nil && req.EmailDomain == "" && req.Phone == "" && req.Address == "" && req.City == "" && req.Region == "" && req.Selector == "" && req.CreatedAfter == nil && req.CreatedBefore == nil {
+				logutil.Errorf("need at least one of '--name', '--agefrom', '--ageto', '--email-domain', '--phone', '--postaladdress', '--city', '--region', '--selector', '--created-after' or '--created-before'")
+				os.Exit(1)
 			}
 
-			ageFrom, err := searchCmd.Flags().GetInt32("agefrom")
+			req.OrderBy, err = parseSort(searchCmd)
 			if err != nil {
-				logutil.Errorf("--agefrom is not a number")
+				logutil.Errorf("%v", err)
 				os.Exit(1)
 			}
-			ageTo, err := searchCmd.Flags().GetInt32("ageto")
+
+			mode, err := parseMatchMode(searchCmd)
 			if err != nil {
-				logutil.Errorf("--ageto is not a number")
+				logutil.Errorf("%v", err)
 				os.Exit(1)
 			}
-			if ageTo != 0 || ageFrom != 0 {
-				searchAge = true
+			if searchCmd.Flags().Changed("max-distance") && mode != pb.SearchNameReq_FUZZY {
+				logutil.Errorf("--max-distance can only be used with --match=fuzzy")
+				os.Exit(1)
 			}
-
-			switch {
-			case searchAge && searchName:
-				logutil.Errorf("cannot search by age and by name at the same time")
-			case !searchAge && !searchName:
-				logutil.Errorf("need one of '--name=PARTIALNAME' or '--agefrom=N' + '--ageto=M'")
-			case searchAge:
-				req := &pb.SearchAgeReq{AgeRange: &pb.SearchAgeReq_AgeRange{From: int32(ageFrom), ToIncluded: int32(ageTo)}}
-
-				resp, err := client.SearchAge(ctx, req)
-				switch {
-				case err != nil:
-					logutil.Errorf("searching age: %v", err)
-					os.Exit(1)
-				case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
-					logutil.Errorf("%s: %s", resp.Status.Code, resp.Status.Msg)
+			if mode != pb.SearchNameReq_SUBSTRING {
+				if req.Name == "" || req.AgeFrom != nil || req.AgeToIncluded != nil || req.EmailDomain != "" || req.Phone != "" || req.Address != "" || req.City != "" || req.Region != "" || req.Selector != "" || req.IncludeDeleted || req.CreatedAfter != nil || req.CreatedBefore != nil {
+					logutil.Errorf("--match=%s can only be used with --name alone", strings.ToLower(mode.String()))
 					os.Exit(1)
-				default:
-					// Happy path continuing below.
 				}
-
-				for _, u := range resp.GetUsers() {
-					fmt.Println(Spprint(u))
+				var maxDistance *wrapperspb.Int32Value
+				if searchCmd.Flags().Changed("max-distance") {
+					d, err := searchCmd.Flags().GetInt32("max-distance")
+					if err != nil {
+						logutil.Errorf("--max-distance is not a number")
+						os.Exit(1)
+					}
+					maxDistance = wrapperspb.Int32(d)
 				}
-			case searchName:
-				resp, err := client.SearchName(ctx, &pb.SearchNameReq{Query: name})
-				switch {
-				case err != nil:
+
+				err = printPages(searchCmd, func(ctx context.Context, pageSize int32, pageToken string) (*pb.SearchResp, error) {
+					return client.SearchName(ctx, &pb.SearchNameReq{Query: req.Name, MatchMode: mode, MaxDistance: maxDistance, PageSize: pageSize, PageToken: pageToken, OrderBy: req.OrderBy})
+				})
+				if err != nil {
 					logutil.Errorf("searching by name: %v", err)
 					os.Exit(1)
-				case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
-					logutil.Errorf("%s: %s", resp.Status.Code, resp.Status.Msg)
-					os.Exit(1)
-				default:
-					// Happy path continuing below.
 				}
+				return
+			}
 
-				for _, u := range resp.GetUsers() {
-					fmt.Println(Spprint(u))
-				}
+			err = printPages(searchCmd, func(ctx context.Context, pageSize int32, pageToken string) (*pb.SearchResp, error) {
+				req.PageSize, req.PageToken = pageSize, pageToken
+				return client.Search(ctx, req)
+			})
+			if err != nil {
+				logutil.Errorf("searching: %v", err)
+				os.Exit(1)
 			}
 		},
 	}
 
 	searchCmd.Flags().String("name", "", "Search with a substring of first or last name; search is case-insensitive and special characters insensitive (e.g., searching 'mael' will return 'Maël') // brianna.shelton@email.org")
-	searchCmd.Flags().Int32("agefrom", 0, "Search in [agefrom, ageto]")
-	searchCmd.Flags().Int32("ageto", 0, "")
+	searchCmd.Flags().Int32("agefrom", 0, "Search users that are at least this old")
+	searchCmd.Flags().Int32("ageto", 0, "Search users that are at most this old")
+	searchCmd.Flags().String("email-domain", "", "Search users whose email ends with @DOMAIN (e.g., 'email.org')")
+	searchCmd.Flags().String("phone", "", "Search with a part of the phone number; only the digits are compared")
+	searchCmd.Flags().String("postaladdress", "", "Search with a substring of the address; case and special characters are ignored")
+	searchCmd.Flags().String("city", "", "Search users living in this city (e.g., 'Volta')")
+	searchCmd.Flags().String("region", "", "Search users living in this region or state (e.g., 'Indiana')")
+	addSelectorFlag(searchCmd)
+	searchCmd.Flags().Bool("include-deleted", false, "Also return the users that were deleted but not purged")
+	searchCmd.Flags().String("match", "substring", "How --name is matched: 'substring', 'fuzzy' (tolerates typos, e.g., 'Brinna' finds 'Brianna') or 'phonetic' (e.g., 'Chelton' finds 'Shelton')")
+	searchCmd.Flags().Bool("fuzzy", false, "Same as --match=fuzzy")
+	searchCmd.Flags().Int32("max-distance", 0, "Maximum number of typos tolerated by --match=fuzzy; 0 only finds the names that contain --name as is (default 2)")
+	addPagingFlags(searchCmd)
+	addSortFlag(searchCmd)
+	addCreatedFlags(searchCmd)
 
 	rootCmd.AddCommand(searchCmd)
 }
+
+var matchModes = map[string]pb.SearchNameReq_MatchMode{
+	"substring": pb.SearchNameReq_SUBSTRING,
+	"fuzzy":     pb.SearchNameReq_FUZZY,
+	"phonetic":  pb.SearchNameReq_PHONETIC,
+}
+
+// parseMatchMode reads --match and --fuzzy.
+func parseMatchMode(cmd *cobra.Command) (pb.SearchNameReq_MatchMode, error) {
+	match, _ := cmd.Flags().GetString("match")
+	mode, ok := matchModes[match]
+	if !ok {
+		return 0, fmt.Errorf("--match: unknown mode '%s', expected one of substring, fuzzy or phonetic", match)
+	}
+
+	fuzzy, _ := cmd.Flags().GetBool("fuzzy")
+	switch {
+	case fuzzy && cmd.Flags().Changed("match") && mode != pb.SearchNameReq_FUZZY:
+		return 0, fmt.Errorf("--fuzzy cannot be used with --match=%s", match)
+	case fuzzy:
+		return pb.SearchNameReq_FUZZY, nil
+	}
+
+	return mode, nil
+}
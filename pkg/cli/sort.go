@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+var sortFields = map[string]pb.OrderBy_Field{
+	"email":     pb.OrderBy_EMAIL,
+	"firstname": pb.OrderBy_FIRST_NAME,
+	"lastname":  pb.OrderBy_LAST_NAME,
+	"age":       pb.OrderBy_AGE,
+}
+
+func addSortFlag(cmd *cobra.Command) {
+	cmd.Flags().String("sort", "", "Comma-separated list of fields to sort by among email, firstname, lastname and age; prefix a field with '-' for descending order (e.g., 'lastname,-age')")
+}
+
+// parseSort turns the value of --sort into sort keys. An empty value means
+// that the server's default order is used.
+func parseSort(cmd *cobra.Command) ([]*pb.OrderBy, error) {
+	value, _ := cmd.Flags().GetString("sort")
+	if value == "" {
+		return nil, nil
+	}
+
+	var orderBy []*pb.OrderBy
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		descending := strings.HasPrefix(key, "-")
+		field, ok := sortFields[strings.TrimPrefix(key, "-")]
+		if !ok {
+			return nil, fmt.Errorf("--sort: unknown field '%s', must be one of email, firstname, lastname or age", key)
+		}
+		orderBy = append(orderBy, &pb.OrderBy{Field: field, Descending: descending})
+	}
+
+	return orderBy, nil
+}
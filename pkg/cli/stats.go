@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	statsCmd := &cobra.Command{
+		Use:   "stats [--age-buckets=18,30,...] [--output=table|json]",
+		Short: "Print the number of users by age, email domain and region",
+		Long: `Print the number of users by age, email domain and region. The deleted
+users are not counted.
+
+The age histogram is made of the buckets delimited by --age-buckets, e.g.
+--age-buckets=30,40 counts the users under 30, between 30 and 39, and 40
+and over.`,
+		Run: func(statsCmd *cobra.Command, args []string) {
+			output, _ := statsCmd.Flags().GetString("output")
+			if output != "table" && output != "json" {
+				logutil.Errorf("--output must be either 'table' or 'json', got '%s'", output)
+				os.Exit(1)
+			}
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			buckets, _ := statsCmd.Flags().GetInt32Slice("age-buckets")
+			resp, err := client.Stats(ctx, &pb.StatsReq{AgeBuckets: buckets})
+			switch {
+			case err != nil:
+				logutil.Errorf("computing the statistics: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf(resp.Status.Msg)
+				os.Exit(1)
+			}
+
+			if output == "json" {
+				err = printStatsJSON(os.Stdout, resp)
+			} else {
+				err = printStatsTable(os.Stdout, resp)
+			}
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	statsCmd.Flags().Int32Slice("age-buckets", nil, "Bounds of the age histogram (default 18,25,35,45,55,65)")
+	statsCmd.Flags().StringP("output", "o", "table", "Either 'table' or 'json'")
+
+	rootCmd.AddCommand(statsCmd)
+}
+
+// bucketName returns e.g. "18-24" for the bucket [18, 25) and "65+" for
+// the last bucket.
+func bucketName(b *pb.AgeBucket) string {
+	if b.To == 0 {
+		return fmt.Sprintf("%d+", b.From)
+	}
+	return fmt.Sprintf("%d-%d", b.From, b.To-1)
+}
+
+func printStatsTable(out io.Writer, s *pb.StatsResp) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "users\t%d\n", s.Total)
+	fmt.Fprintf(w, "age\tmin %d, max %d, mean %.1f, median %.1f\n", s.MinAge, s.MaxAge, s.MeanAge, s.MedianAge)
+
+	fmt.Fprintf(w, "by age\n")
+	for _, b := range s.AgeBuckets {
+		fmt.Fprintf(w, "  %s\t%d\n", bucketName(b), b.Count)
+	}
+	fmt.Fprintf(w, "by email domain\n")
+	for _, c := range s.EmailDomains {
+		fmt.Fprintf(w, "  %s\t%d\n", c.Key, c.Count)
+	}
+	fmt.Fprintf(w, "by region\n")
+	for _, c := range s.Regions {
+		fmt.Fprintf(w, "  %s\t%d\n", c.Key, c.Count)
+	}
+
+	return w.Flush()
+}
+
+type statsJSON struct {
+	Total        int32           `json:"total"`
+	MinAge       int32           `json:"minAge"`
+	MaxAge       int32           `json:"maxAge"`
+	MeanAge      float64         `json:"meanAge"`
+	MedianAge    float64         `json:"medianAge"`
+	AgeBuckets   []ageBucketJSON `json:"ageBuckets"`
+	EmailDomains []countJSON     `json:"emailDomains"`
+	Regions      []countJSON     `json:"regions"`
+}
+
+type ageBucketJSON struct {
+	From  int32 `json:"from"`
+	To    int32 `json:"to,omitempty"` // Excluded; omitted for the last bucket.
+	Count int32 `json:"count"`
+}
+
+type countJSON struct {
+	Key   string `json:"key"`
+	Count int32  `json:"count"`
+}
+
+func printStatsJSON(out io.Writer, s *pb.StatsResp) error {
+	res := statsJSON{
+		Total:        s.Total,
+		MinAge:       s.MinAge,
+		MaxAge:       s.MaxAge,
+		MeanAge:      s.MeanAge,
+		MedianAge:    s.MedianAge,
+		AgeBuckets:   []ageBucketJSON{},
+		EmailDomains: []countJSON{},
+		Regions:      []countJSON{},
+	}
+	for _, b := range s.AgeBuckets {
+		res.AgeBuckets = append(res.AgeBuckets, ageBucketJSON{From: b.From, To: b.To, Count: b.Count})
+	}
+	for _, c := range s.EmailDomains {
+		res.EmailDomains = append(res.EmailDomains, countJSON{Key: c.Key, Count: c.Count})
+	}
+	for _, c := range s.Regions {
+		res.Regions = append(res.Regions, countJSON{Key: c.Key, Count: c.Count})
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maelvls/users-grpc/pkg/cli/logutil"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func init() {
+	updateCmd := &cobra.Command{
+		Use:   "update EMAIL [--firstname] [--lastname] [--age] [--phone] [--postaladdress | [--city] [--region]] [--label=KEY=VALUE | --label=KEY-]... [--manager=EMAIL] [--if-match=ETAG]",
+		Short: "Update some fields of a user; only the given flags are updated",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("requires an email as argument")
+			}
+			return nil
+		},
+		Run: func(updateCmd *cobra.Command, args []string) {
+			givenEmail := args[0]
+
+			// Only the flags that were explicitly given are sent so that
+			// the other fields are left untouched on the server.
+			usr := &pb.User{Name: &pb.Name{}, Address: &pb.Address{}}
+			mask := &fieldmaskpb.FieldMask{}
+			if updateCmd.Flags().Changed("firstname") {
+				usr.Name.First, _ = updateCmd.Flags().GetString("firstname")
+				mask.Paths = append(mask.Paths, "name.first")
+			}
+			if updateCmd.Flags().Changed("lastname") {
+				usr.Name.Last, _ = updateCmd.Flags().GetString("lastname")
+				mask.Paths = append(mask.Paths, "name.last")
+			}
+			if updateCmd.Flags().Changed("age") {
+				usr.Age, _ = updateCmd.Flags().GetInt32("age")
+				mask.Paths = append(mask.Paths, "age")
+			}
+			if updateCmd.Flags().Changed("phone") {
+				usr.Phone, _ = updateCmd.Flags().GetString("phone")
+				mask.Paths = append(mask.Paths, "phone")
+			}
+			if updateCmd.Flags().Changed("postaladdress") {
+				if updateCmd.Flags().Changed("city") || updateCmd.Flags().Changed("region") {
+					logutil.Errorf("--city and --region cannot be used along with --postaladdress")
+					os.Exit(1)
+				}
+				postaladdress, _ := updateCmd.Flags().GetString("postaladdress")
+				address, err := parseAddress(postaladdress)
+				if err != nil {
+					logutil.Errorf("--postaladdress: %v", err)
+					os.Exit(1)
+				}
+				usr.Address = address
+				mask.Paths = append(mask.Paths, "address")
+			}
+			if updateCmd.Flags().Changed("city") {
+				usr.Address.City, _ = updateCmd.Flags().GetString("city")
+				mask.Paths = append(mask.Paths, "address.city")
+			}
+			if updateCmd.Flags().Changed("region") {
+				usr.Address.Region, _ = updateCmd.Flags().GetString("region")
+				mask.Paths = append(mask.Paths, "address.region")
+			}
+			labels, removed, err := parseLabels(updateCmd, true)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+			if len(labels) > 0 {
+				usr.Labels = labels
+			}
+			for _, key := range sortedKeys(labels) {
+				mask.Paths = append(mask.Paths, "labels."+key)
+			}
+			for _, key := range removed {
+				mask.Paths = append(mask.Paths, "labels."+key)
+			}
+			if updateCmd.Flags().Changed("manager") {
+				mask.Paths = append(mask.Paths, "manager_id")
+			}
+			if len(mask.Paths) == 0 {
+				logutil.Errorf("nothing to update, give at least one of --firstname, --lastname, --age, --phone, --postaladdress, --city, --region, --label or --manager")
+				os.Exit(1)
+			}
+
+			client, err := createClient(cfg)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			usr.ManagerId, err = parseManager(ctx, client, updateCmd)
+			if err != nil {
+				logutil.Errorf("%v", err)
+				os.Exit(1)
+			}
+
+			etag, _ := updateCmd.Flags().GetString("if-match")
+			resp, err := client.Update(ctx, &pb.UpdateReq{Email: givenEmail, User: usr, UpdateMask: mask, Etag: etag})
+			switch {
+			case err != nil:
+				logutil.Errorf("updating user: %v", err)
+				os.Exit(1)
+			case resp.GetStatus().GetCode() == pb.Status_CONFLICT:
+				exitWithConflict(resp.Status, givenEmail)
+			case resp.GetStatus().GetCode() != pb.Status_SUCCESS:
+				logutil.Errorf("%s: %s", resp.Status.Code, resp.Status.Msg)
+				os.Exit(1)
+			default:
+				// Happy path continuing below.
+			}
+
+			fmt.Println(Spprint(resp.User))
+		},
+	}
+
+	updateCmd.Flags().String("firstname", "", "")
+	updateCmd.Flags().String("lastname", "", "")
+	updateCmd.Flags().Int32("age", 0, "")
+	updateCmd.Flags().String("phone", "", "")         // +1 (814) 482-3880
+	updateCmd.Flags().String("postaladdress", "", "") // 255 Cortelyou Road, Volta, Indiana, 1608
+	updateCmd.Flags().String("city", "", "Only update the city of the address")
+	updateCmd.Flags().String("region", "", "Only update the region (or state) of the address")
+	addLabelFlag(updateCmd, true)
+	addManagerFlag(updateCmd, "The email of the user this user reports to; --manager='' removes the manager")
+	addIfMatchFlag(updateCmd)
+
+	rootCmd.AddCommand(updateCmd)
+}
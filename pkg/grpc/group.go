@@ -0,0 +1,266 @@
+package grpc
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+
+	service "github.com/maelvls/users-grpc/pkg/service"
+	pb "github.com/maelvls/users-grpc/schema/user"
+)
+
+// For testing purposes.
+type GroupService interface {
+	CreateGroup(txn *memdb.Txn, group service.Group) (service.Group, error)
+	DeleteGroup(txn *memdb.Txn, name string) (service.Group, error)
+	GetGroup(txn *memdb.Txn, name string) (service.Group, error)
+	ListGroups(txn *memdb.Txn) ([]service.Group, error)
+	AddMember(txn *memdb.Txn, name, email string) (service.Group, error)
+	RemoveMember(txn *memdb.Txn, name, email string) (service.Group, error)
+	Members(txn *memdb.Txn, name string) ([]service.User, error)
+	GroupsOf(txn *memdb.Txn, email string) ([]service.Group, error)
+}
+
+// GroupServer implements the GRPC endpoints of the "group" service. It
+// shares the database of the "user" service since the members are users.
+type GroupServer struct {
+	Txn      func(write bool) *memdb.Txn
+	Commit   func(*memdb.Txn)
+	Rollback func(*memdb.Txn)
+
+	// For testing purposes.
+	Svc GroupService
+}
+
+// NewGroupServer returns a new server that uses the same database and the
+// same clock as the given user server.
+func NewGroupServer(users *UserServer) *GroupServer {
+	svc, _ := users.Svc.(service.UserSvc)
+	return &GroupServer{
+		Txn:      users.Txn,
+		Commit:   users.Commit,
+		Rollback: users.Rollback,
+		Svc:      service.GroupSvc{Clock: svc.Clock},
+	}
+}
+
+// CreateGroup creates a group without members.
+func (server *GroupServer) CreateGroup(ctx context.Context, req *pb.CreateGroupReq) (*pb.CreateGroupResp, error) {
+	logrus.WithField("group", req.Name).Info("create group request received")
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	group, err := server.Svc.CreateGroup(txn, service.Group{Name: req.Name, Description: req.Description})
+	switch {
+	case err == service.GroupNameEmpty:
+		return &pb.CreateGroupResp{Group: &pb.Group{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
+	case err == service.GroupAlreadyExists:
+		return &pb.CreateGroupResp{Group: &pb.Group{}, Status: &pb.Status{
+			Code: pb.Status_FAILED,
+			Msg:  fmt.Sprintf("the group %s already exists", req.Name),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("group", req.Name).Error("CreateGroup returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while creating group, name=" + req.Name)
+	}
+
+	server.Commit(txn)
+
+	return &pb.CreateGroupResp{Group: groupToPB(group), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// DeleteGroup deletes a group; its members are not deleted.
+func (server *GroupServer) DeleteGroup(ctx context.Context, req *pb.DeleteGroupReq) (*pb.DeleteGroupResp, error) {
+	logrus.WithField("group", req.Name).Info("delete group request received")
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	group, err := server.Svc.DeleteGroup(txn, req.Name)
+	switch {
+	case err == service.GroupNotFound:
+		return &pb.DeleteGroupResp{Group: &pb.Group{}, Status: groupNotFound(req.Name)}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("group", req.Name).Error("DeleteGroup returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while deleting group, name=" + req.Name)
+	}
+
+	server.Commit(txn)
+
+	return &pb.DeleteGroupResp{Group: groupToPB(group), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// GetGroup returns a group by its name.
+func (server *GroupServer) GetGroup(ctx context.Context, req *pb.GetGroupReq) (*pb.GetGroupResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	group, err := server.Svc.GetGroup(txn, req.Name)
+	switch {
+	case err == service.GroupNotFound:
+		return &pb.GetGroupResp{Group: &pb.Group{}, Status: groupNotFound(req.Name)}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("group", req.Name).Error("GetGroup returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while getting group, name=" + req.Name)
+	}
+
+	return &pb.GetGroupResp{Group: groupToPB(group), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// ListGroups returns all the groups sorted by name.
+func (server *GroupServer) ListGroups(ctx context.Context, req *pb.ListGroupsReq) (*pb.ListGroupsResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	groups, err := server.Svc.ListGroups(txn)
+	if err != nil {
+		logrus.WithError(err).Error("ListGroups returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while listing groups")
+	}
+
+	return &pb.ListGroupsResp{Groups: groupsToPB(groups), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// AddMembers adds the users to the group. The users that cannot be added
+// are reported in the failures while the other ones are still added.
+func (server *GroupServer) AddMembers(ctx context.Context, req *pb.AddMembersReq) (*pb.AddMembersResp, error) {
+	logrus.WithField("group", req.Name).WithField("emails", req.Emails).Info("add members request received")
+	group, failures, status, err := server.changeMembers(req.Name, req.Emails, "added", server.Svc.AddMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AddMembersResp{Group: group, Failures: failures, Status: status}, nil
+}
+
+// RemoveMembers removes the users from the group, the same way as
+// AddMembers.
+func (server *GroupServer) RemoveMembers(ctx context.Context, req *pb.RemoveMembersReq) (*pb.RemoveMembersResp, error) {
+	logrus.WithField("group", req.Name).WithField("emails", req.Emails).Info("remove members request received")
+	group, failures, status, err := server.changeMembers(req.Name, req.Emails, "removed", server.Svc.RemoveMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RemoveMembersResp{Group: group, Failures: failures, Status: status}, nil
+}
+
+// changeMembers calls change (AddMember or RemoveMember) for each email in
+// a single transaction. The verb, e.g. "added", is used in the messages.
+func (server *GroupServer) changeMembers(name string, emails []string, verb string, change func(txn *memdb.Txn, name, email string) (service.Group, error)) (*pb.Group, []*pb.MemberFailure, *pb.Status, error) {
+	if len(emails) == 0 {
+		return &pb.Group{}, nil, &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "at least one email must be given"}, nil
+	}
+
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	group, err := server.Svc.GetGroup(txn, name)
+	switch {
+	case err == service.GroupNotFound:
+		return &pb.Group{}, nil, groupNotFound(name), nil
+	case err != nil:
+		logrus.WithError(err).WithField("group", name).Error("GetGroup returned an unexpected error")
+		return nil, nil, nil, fmt.Errorf("something wrong happened while getting group, name=" + name)
+	}
+
+	var failures []*pb.MemberFailure
+	for _, email := range emails {
+		changed, err := change(txn, name, email)
+		switch {
+		case err == service.EmailNotFound:
+			failures = append(failures, &pb.MemberFailure{Email: email, Status: &pb.Status{
+				Code: pb.Status_INVALID_QUERY,
+				Msg:  fmt.Sprintf("the email %s cannot be found", email),
+			}})
+			continue
+		case err == service.AlreadyMember, err == service.NotMember:
+			failures = append(failures, &pb.MemberFailure{Email: email, Status: &pb.Status{
+				Code: pb.Status_FAILED,
+				Msg:  err.Error(),
+			}})
+			continue
+		case err != nil:
+			logrus.WithError(err).WithField("group", name).WithField("email", email).Error("changing the members returned an unexpected error")
+			return nil, nil, nil, fmt.Errorf("something wrong happened while changing the members of group, name=%s, email=%s", name, email)
+		}
+		group = changed
+	}
+
+	server.Commit(txn)
+
+	var status *pb.Status
+	switch {
+	case len(failures) == 0:
+		status = &pb.Status{Code: pb.Status_SUCCESS}
+	case len(failures) == len(emails):
+		status = &pb.Status{Code: pb.Status_FAILED, Msg: fmt.Sprintf("none of the %d users could be %s", len(emails), verb)}
+	default:
+		status = &pb.Status{Code: pb.Status_PARTIAL_SUCCESS, Msg: fmt.Sprintf("%d users %s, %d rejected", len(emails)-len(failures), verb, len(failures))}
+	}
+
+	return groupToPB(group), failures, status, nil
+}
+
+// ListMembers returns the members of a group sorted by email.
+func (server *GroupServer) ListMembers(ctx context.Context, req *pb.ListMembersReq) (*pb.ListMembersResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	users, err := server.Svc.Members(txn, req.Name)
+	switch {
+	case err == service.GroupNotFound:
+		return &pb.ListMembersResp{Status: groupNotFound(req.Name)}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("group", req.Name).Error("Members returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while listing the members of group, name=" + req.Name)
+	}
+
+	return &pb.ListMembersResp{Users: ToPBs(users), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// ListGroupsOfUser returns the groups of a user sorted by name.
+func (server *GroupServer) ListGroupsOfUser(ctx context.Context, req *pb.ListGroupsOfUserReq) (*pb.ListGroupsOfUserResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	groups, err := server.Svc.GroupsOf(txn, req.Email)
+	switch {
+	case err == service.EmailNotFound:
+		return &pb.ListGroupsOfUserResp{Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the email %s cannot be found", req.Email),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("GroupsOf returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while listing the groups of user, email=" + req.Email)
+	}
+
+	return &pb.ListGroupsOfUserResp{Groups: groupsToPB(groups), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+func groupNotFound(name string) *pb.Status {
+	return &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: fmt.Sprintf("the group %s cannot be found", name)}
+}
+
+func groupToPB(g service.Group) *pb.Group {
+	return &pb.Group{
+		Id:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		MemberCount: int32(len(g.Members)),
+		CreatedAt:   toPBTime(g.CreatedAt),
+	}
+}
+
+func groupsToPB(groups []service.Group) []*pb.Group {
+	res := make([]*pb.Group, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, groupToPB(g))
+	}
+	return res
+}
@@ -0,0 +1,240 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/maelvls/users-grpc/pkg/grpc/mocks"
+	service "github.com/maelvls/users-grpc/pkg/service"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	td "github.com/maxatome/go-testdeep"
+)
+
+func TestGroupServer_CreateGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.CreateGroupReq
+		givenMock func(rec *mocks.MockGroupServiceMockRecorder)
+		want      *pb.CreateGroupResp
+		wantErr   error
+	}{
+		{
+			name:     "when a group is created, it returns it",
+			givenReq: &pb.CreateGroupReq{Name: "payments", Description: "The payments team"},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.CreateGroup(someTxn(), service.Group{Name: "payments", Description: "The payments team"}).
+					Return(service.Group{ID: "g1", Name: "payments", Description: "The payments team"}, nil)
+			},
+			want: &pb.CreateGroupResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Group:  &pb.Group{Id: "g1", Name: "payments", Description: "The payments team"},
+			},
+		},
+		{
+			name:     "when the group already exists, return an understandable message",
+			givenReq: &pb.CreateGroupReq{Name: "payments"},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.CreateGroup(someTxn(), service.Group{Name: "payments"}).Return(service.Group{}, service.GroupAlreadyExists)
+			},
+			want: &pb.CreateGroupResp{Group: &pb.Group{}, Status: &pb.Status{Code: pb.Status_FAILED, Msg: "the group payments already exists"}},
+		},
+		{
+			name:     "when the name is empty, return an understandable message",
+			givenReq: &pb.CreateGroupReq{},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.CreateGroup(someTxn(), service.Group{}).Return(service.Group{}, service.GroupNameEmpty)
+			},
+			want: &pb.CreateGroupResp{Group: &pb.Group{}, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the group name cannot be empty"}},
+		},
+		{
+			name:     "unknown CreateGroup errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.CreateGroupReq{Name: "payments"},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.CreateGroup(someTxn(), service.Group{Name: "payments"}).Return(service.Group{}, fmt.Errorf("some random error"))
+			},
+			wantErr: fmt.Errorf("something wrong happened while creating group, name=payments"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockGroupSvc := mocks.NewMockGroupService(ctl)
+			tt.givenMock(mockGroupSvc.EXPECT())
+
+			got, gotErr := newTestGroupServer(mockGroupSvc).CreateGroup(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupServer_AddMembers(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.AddMembersReq
+		givenMock func(rec *mocks.MockGroupServiceMockRecorder)
+		want      *pb.AddMembersResp
+		wantErr   error
+	}{
+		{
+			name:     "when all the users are added, it returns the group",
+			givenReq: &pb.AddMembersReq{Name: "payments", Emails: []string{"a@pod.ru", "b@pod.ru"}},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.GetGroup(someTxn(), "payments").Return(service.Group{ID: "g1", Name: "payments"}, nil)
+				rec.AddMember(someTxn(), "payments", "a@pod.ru").Return(service.Group{ID: "g1", Name: "payments", Members: []string{"a1"}}, nil)
+				rec.AddMember(someTxn(), "payments", "b@pod.ru").Return(service.Group{ID: "g1", Name: "payments", Members: []string{"a1", "a2"}}, nil)
+			},
+			want: &pb.AddMembersResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Group:  &pb.Group{Id: "g1", Name: "payments", MemberCount: 2},
+			},
+		},
+		{
+			name:     "when some users cannot be added, the other ones still are",
+			givenReq: &pb.AddMembersReq{Name: "payments", Emails: []string{"a@pod.ru", "b@pod.ru", "c@pod.ru"}},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.GetGroup(someTxn(), "payments").Return(service.Group{ID: "g1", Name: "payments", Members: []string{"a2"}}, nil)
+				rec.AddMember(someTxn(), "payments", "a@pod.ru").Return(service.Group{ID: "g1", Name: "payments", Members: []string{"a2", "a1"}}, nil)
+				rec.AddMember(someTxn(), "payments", "b@pod.ru").Return(service.Group{}, service.AlreadyMember)
+				rec.AddMember(someTxn(), "payments", "c@pod.ru").Return(service.Group{}, service.EmailNotFound)
+			},
+			want: &pb.AddMembersResp{
+				Status: &pb.Status{Code: pb.Status_PARTIAL_SUCCESS, Msg: "1 users added, 2 rejected"},
+				Group:  &pb.Group{Id: "g1", Name: "payments", MemberCount: 2},
+				Failures: []*pb.MemberFailure{
+					{Email: "b@pod.ru", Status: &pb.Status{Code: pb.Status_FAILED, Msg: "the user is already a member of the group"}},
+					{Email: "c@pod.ru", Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email c@pod.ru cannot be found"}},
+				},
+			},
+		},
+		{
+			name:     "when none of the users can be added, return FAILED",
+			givenReq: &pb.AddMembersReq{Name: "payments", Emails: []string{"c@pod.ru"}},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.GetGroup(someTxn(), "payments").Return(service.Group{ID: "g1", Name: "payments"}, nil)
+				rec.AddMember(someTxn(), "payments", "c@pod.ru").Return(service.Group{}, service.EmailNotFound)
+			},
+			want: &pb.AddMembersResp{
+				Status: &pb.Status{Code: pb.Status_FAILED, Msg: "none of the 1 users could be added"},
+				Group:  &pb.Group{Id: "g1", Name: "payments"},
+				Failures: []*pb.MemberFailure{
+					{Email: "c@pod.ru", Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email c@pod.ru cannot be found"}},
+				},
+			},
+		},
+		{
+			name:     "when the group does not exist, return an understandable message",
+			givenReq: &pb.AddMembersReq{Name: "payments", Emails: []string{"a@pod.ru"}},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.GetGroup(someTxn(), "payments").Return(service.Group{}, service.GroupNotFound)
+			},
+			want: &pb.AddMembersResp{Group: &pb.Group{}, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the group payments cannot be found"}},
+		},
+		{
+			name:      "when no email is given, return an understandable message",
+			givenReq:  &pb.AddMembersReq{Name: "payments"},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {},
+			want:      &pb.AddMembersResp{Group: &pb.Group{}, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "at least one email must be given"}},
+		},
+		{
+			name:     "unknown AddMember errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.AddMembersReq{Name: "payments", Emails: []string{"a@pod.ru"}},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.GetGroup(someTxn(), "payments").Return(service.Group{ID: "g1", Name: "payments"}, nil)
+				rec.AddMember(someTxn(), "payments", "a@pod.ru").Return(service.Group{}, fmt.Errorf("some random error"))
+			},
+			wantErr: fmt.Errorf("something wrong happened while changing the members of group, name=payments, email=a@pod.ru"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockGroupSvc := mocks.NewMockGroupService(ctl)
+			tt.givenMock(mockGroupSvc.EXPECT())
+
+			got, gotErr := newTestGroupServer(mockGroupSvc).AddMembers(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupServer_ListGroupsOfUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.ListGroupsOfUserReq
+		givenMock func(rec *mocks.MockGroupServiceMockRecorder)
+		want      *pb.ListGroupsOfUserResp
+		wantErr   error
+	}{
+		{
+			name:     "when the user is found, it returns its groups",
+			givenReq: &pb.ListGroupsOfUserReq{Email: "a@pod.ru"},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.GroupsOf(someTxn(), "a@pod.ru").Return([]service.Group{
+					{ID: "g2", Name: "admins", Members: []string{"a1"}},
+					{ID: "g1", Name: "payments", Members: []string{"a1", "a2"}},
+				}, nil)
+			},
+			want: &pb.ListGroupsOfUserResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Groups: []*pb.Group{
+					{Id: "g2", Name: "admins", MemberCount: 1},
+					{Id: "g1", Name: "payments", MemberCount: 2},
+				},
+			},
+		},
+		{
+			name:     "when the user cannot be found, return an understandable message",
+			givenReq: &pb.ListGroupsOfUserReq{Email: "a@pod.ru"},
+			givenMock: func(rec *mocks.MockGroupServiceMockRecorder) {
+				rec.GroupsOf(someTxn(), "a@pod.ru").Return(nil, service.EmailNotFound)
+			},
+			want: &pb.ListGroupsOfUserResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email a@pod.ru cannot be found"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockGroupSvc := mocks.NewMockGroupService(ctl)
+			tt.givenMock(mockGroupSvc.EXPECT())
+
+			got, gotErr := newTestGroupServer(mockGroupSvc).ListGroupsOfUser(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestGroupServer(svc GroupService) *GroupServer {
+	return &GroupServer{
+		Txn:      func(b bool) *memdb.Txn { return nil },
+		Commit:   func(m *memdb.Txn) {},
+		Rollback: func(m *memdb.Txn) {},
+		Svc:      svc,
+	}
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../group.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	memdb "github.com/hashicorp/go-memdb"
+	service "github.com/maelvls/users-grpc/pkg/service"
+	reflect "reflect"
+)
+
+// MockGroupService is a mock of GroupService interface
+type MockGroupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupServiceMockRecorder
+}
+
+// MockGroupServiceMockRecorder is the mock recorder for MockGroupService
+type MockGroupServiceMockRecorder struct {
+	mock *MockGroupService
+}
+
+// NewMockGroupService creates a new mock instance
+func NewMockGroupService(ctrl *gomock.Controller) *MockGroupService {
+	mock := &MockGroupService{ctrl: ctrl}
+	mock.recorder = &MockGroupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGroupService) EXPECT() *MockGroupServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateGroup mocks base method
+func (m *MockGroupService) CreateGroup(txn *memdb.Txn, group service.Group) (service.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", txn, group)
+	ret0, _ := ret[0].(service.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup
+func (mr *MockGroupServiceMockRecorder) CreateGroup(txn, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupService)(nil).CreateGroup), txn, group)
+}
+
+// DeleteGroup mocks base method
+func (m *MockGroupService) DeleteGroup(txn *memdb.Txn, name string) (service.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", txn, name)
+	ret0, _ := ret[0].(service.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup
+func (mr *MockGroupServiceMockRecorder) DeleteGroup(txn, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupService)(nil).DeleteGroup), txn, name)
+}
+
+// GetGroup mocks base method
+func (m *MockGroupService) GetGroup(txn *memdb.Txn, name string) (service.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", txn, name)
+	ret0, _ := ret[0].(service.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup
+func (mr *MockGroupServiceMockRecorder) GetGroup(txn, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockGroupService)(nil).GetGroup), txn, name)
+}
+
+// ListGroups mocks base method
+func (m *MockGroupService) ListGroups(txn *memdb.Txn) ([]service.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", txn)
+	ret0, _ := ret[0].([]service.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups
+func (mr *MockGroupServiceMockRecorder) ListGroups(txn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockGroupService)(nil).ListGroups), txn)
+}
+
+// AddMember mocks base method
+func (m *MockGroupService) AddMember(txn *memdb.Txn, name, email string) (service.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", txn, name, email)
+	ret0, _ := ret[0].(service.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember
+func (mr *MockGroupServiceMockRecorder) AddMember(txn, name, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockGroupService)(nil).AddMember), txn, name, email)
+}
+
+// RemoveMember mocks base method
+func (m *MockGroupService) RemoveMember(txn *memdb.Txn, name, email string) (service.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", txn, name, email)
+	ret0, _ := ret[0].(service.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember
+func (mr *MockGroupServiceMockRecorder) RemoveMember(txn, name, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupService)(nil).RemoveMember), txn, name, email)
+}
+
+// Members mocks base method
+func (m *MockGroupService) Members(txn *memdb.Txn, name string) ([]service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Members", txn, name)
+	ret0, _ := ret[0].([]service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Members indicates an expected call of Members
+func (mr *MockGroupServiceMockRecorder) Members(txn, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockGroupService)(nil).Members), txn, name)
+}
+
+// GroupsOf mocks base method
+func (m *MockGroupService) GroupsOf(txn *memdb.Txn, email string) ([]service.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupsOf", txn, email)
+	ret0, _ := ret[0].([]service.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupsOf indicates an expected call of GroupsOf
+func (mr *MockGroupServiceMockRecorder) GroupsOf(txn, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupsOf", reflect.TypeOf((*MockGroupService)(nil).GroupsOf), txn, email)
+}
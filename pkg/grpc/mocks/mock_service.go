@@ -49,61 +49,364 @@ func (mr *MockUserServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Ca
 }
 
 // List mocks base method
-func (m *MockUserService) List(arg0 *memdb.Txn) ([]service.User, error) {
+func (m *MockUserService) List(txn *memdb.Txn, includeDeleted bool, created service.TimeRange, selector service.Selector, page service.Page) ([]service.User, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0)
+	ret := m.ctrl.Call(m, "List", txn, includeDeleted, created, selector, page)
 	ret0, _ := ret[0].([]service.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // List indicates an expected call of List
-func (mr *MockUserServiceMockRecorder) List(arg0 interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) List(txn, includeDeleted, created, selector, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserService)(nil).List), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserService)(nil).List), txn, includeDeleted, created, selector, page)
 }
 
 // SearchAge mocks base method
-func (m *MockUserService) SearchAge(txn *memdb.Txn, ageFrom, ageTo int32) ([]service.User, error) {
+func (m *MockUserService) SearchAge(txn *memdb.Txn, ageFrom, ageTo int32, page service.Page) ([]service.User, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchAge", txn, ageFrom, ageTo)
+	ret := m.ctrl.Call(m, "SearchAge", txn, ageFrom, ageTo, page)
 	ret0, _ := ret[0].([]service.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // SearchAge indicates an expected call of SearchAge
-func (mr *MockUserServiceMockRecorder) SearchAge(txn, ageFrom, ageTo interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) SearchAge(txn, ageFrom, ageTo, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAge", reflect.TypeOf((*MockUserService)(nil).SearchAge), txn, ageFrom, ageTo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAge", reflect.TypeOf((*MockUserService)(nil).SearchAge), txn, ageFrom, ageTo, page)
 }
 
 // SearchName mocks base method
-func (m *MockUserService) SearchName(txn *memdb.Txn, query string) ([]service.User, error) {
+func (m *MockUserService) SearchName(txn *memdb.Txn, query string, page service.Page) ([]service.User, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchName", txn, query)
+	ret := m.ctrl.Call(m, "SearchName", txn, query, page)
 	ret0, _ := ret[0].([]service.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // SearchName indicates an expected call of SearchName
-func (mr *MockUserServiceMockRecorder) SearchName(txn, query interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) SearchName(txn, query, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchName", reflect.TypeOf((*MockUserService)(nil).SearchName), txn, query, page)
+}
+
+// SearchNameFuzzy mocks base method
+func (m *MockUserService) SearchNameFuzzy(txn *memdb.Txn, query string, maxDistance *int32, page service.Page) ([]service.Match, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchNameFuzzy", txn, query, maxDistance, page)
+	ret0, _ := ret[0].([]service.Match)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchNameFuzzy indicates an expected call of SearchNameFuzzy
+func (mr *MockUserServiceMockRecorder) SearchNameFuzzy(txn, query, maxDistance, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchName", reflect.TypeOf((*MockUserService)(nil).SearchName), txn, query)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNameFuzzy", reflect.TypeOf((*MockUserService)(nil).SearchNameFuzzy), txn, query, maxDistance, page)
+}
+
+// SearchNamePhonetic mocks base method
+func (m *MockUserService) SearchNamePhonetic(txn *memdb.Txn, query string, page service.Page) ([]service.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchNamePhonetic", txn, query, page)
+	ret0, _ := ret[0].([]service.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchNamePhonetic indicates an expected call of SearchNamePhonetic
+func (mr *MockUserServiceMockRecorder) SearchNamePhonetic(txn, query, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNamePhonetic", reflect.TypeOf((*MockUserService)(nil).SearchNamePhonetic), txn, query, page)
+}
+
+// Search mocks base method
+func (m *MockUserService) Search(txn *memdb.Txn, query service.SearchQuery, page service.Page) ([]service.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", txn, query, page)
+	ret0, _ := ret[0].([]service.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search
+func (mr *MockUserServiceMockRecorder) Search(txn, query, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserService)(nil).Search), txn, query, page)
+}
+
+// StreamList mocks base method
+func (m *MockUserService) StreamList(txn *memdb.Txn, includeDeleted bool, created service.TimeRange, selector service.Selector, fn func(service.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamList", txn, includeDeleted, created, selector, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamList indicates an expected call of StreamList
+func (mr *MockUserServiceMockRecorder) StreamList(txn, includeDeleted, created, selector, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamList", reflect.TypeOf((*MockUserService)(nil).StreamList), txn, includeDeleted, created, selector, fn)
+}
+
+// StreamSearchAge mocks base method
+func (m *MockUserService) StreamSearchAge(txn *memdb.Txn, ageFrom, ageTo int32, fn func(service.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSearchAge", txn, ageFrom, ageTo, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSearchAge indicates an expected call of StreamSearchAge
+func (mr *MockUserServiceMockRecorder) StreamSearchAge(txn, ageFrom, ageTo, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSearchAge", reflect.TypeOf((*MockUserService)(nil).StreamSearchAge), txn, ageFrom, ageTo, fn)
+}
+
+// StreamSearchName mocks base method
+func (m *MockUserService) StreamSearchName(txn *memdb.Txn, query string, fn func(service.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSearchName", txn, query, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSearchName indicates an expected call of StreamSearchName
+func (mr *MockUserServiceMockRecorder) StreamSearchName(txn, query, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSearchName", reflect.TypeOf((*MockUserService)(nil).StreamSearchName), txn, query, fn)
 }
 
 // GetByEmail mocks base method
-func (m *MockUserService) GetByEmail(txn *memdb.Txn, email string) (service.User, error) {
+func (m *MockUserService) GetByEmail(txn *memdb.Txn, email string, includeDeleted bool) (service.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByEmail", txn, email)
+	ret := m.ctrl.Call(m, "GetByEmail", txn, email, includeDeleted)
 	ret0, _ := ret[0].(service.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByEmail indicates an expected call of GetByEmail
-func (mr *MockUserServiceMockRecorder) GetByEmail(txn, email interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) GetByEmail(txn, email, includeDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserService)(nil).GetByEmail), txn, email, includeDeleted)
+}
+
+// GetByID mocks base method
+func (m *MockUserService) GetByID(txn *memdb.Txn, id string) (service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", txn, id)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID
+func (mr *MockUserServiceMockRecorder) GetByID(txn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserService)(nil).GetByID), txn, id)
+}
+
+// GetByPhone mocks base method
+func (m *MockUserService) GetByPhone(txn *memdb.Txn, phone string) (service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPhone", txn, phone)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPhone indicates an expected call of GetByPhone
+func (mr *MockUserServiceMockRecorder) GetByPhone(txn, phone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPhone", reflect.TypeOf((*MockUserService)(nil).GetByPhone), txn, phone)
+}
+
+// Update mocks base method
+func (m *MockUserService) Update(txn *memdb.Txn, email string, fields []string, user service.User, etag string) (service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", txn, email, fields, user, etag)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockUserServiceMockRecorder) Update(txn, email, fields, user, etag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserService)(nil).Update), txn, email, fields, user, etag)
+}
+
+// Delete mocks base method
+func (m *MockUserService) Delete(txn *memdb.Txn, email, id, etag string) (service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", txn, email, id, etag)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockUserServiceMockRecorder) Delete(txn, email, id, etag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserService)(nil).Delete), txn, email, id, etag)
+}
+
+// ChangeEmail mocks base method
+func (m *MockUserService) ChangeEmail(txn *memdb.Txn, oldEmail, newEmail, etag string) (service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", txn, oldEmail, newEmail, etag)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail
+func (mr *MockUserServiceMockRecorder) ChangeEmail(txn, oldEmail, newEmail, etag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockUserService)(nil).ChangeEmail), txn, oldEmail, newEmail, etag)
+}
+
+// Restore mocks base method
+func (m *MockUserService) Restore(txn *memdb.Txn, email, etag string) (service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", txn, email, etag)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore
+func (mr *MockUserServiceMockRecorder) Restore(txn, email, etag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserService)(nil).Restore), txn, email, etag)
+}
+
+// Purge mocks base method
+func (m *MockUserService) Purge(txn *memdb.Txn, email, etag string) (service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", txn, email, etag)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge
+func (mr *MockUserServiceMockRecorder) Purge(txn, email, etag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockUserService)(nil).Purge), txn, email, etag)
+}
+
+// Stats mocks base method
+func (m *MockUserService) Stats(txn *memdb.Txn, ageBuckets []int32) (service.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", txn, ageBuckets)
+	ret0, _ := ret[0].(service.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats
+func (mr *MockUserServiceMockRecorder) Stats(txn, ageBuckets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockUserService)(nil).Stats), txn, ageBuckets)
+}
+
+// DirectReports mocks base method
+func (m *MockUserService) DirectReports(txn *memdb.Txn, email string) ([]service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DirectReports", txn, email)
+	ret0, _ := ret[0].([]service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DirectReports indicates an expected call of DirectReports
+func (mr *MockUserServiceMockRecorder) DirectReports(txn, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DirectReports", reflect.TypeOf((*MockUserService)(nil).DirectReports), txn, email)
+}
+
+// ReportingChain mocks base method
+func (m *MockUserService) ReportingChain(txn *memdb.Txn, email string) ([]service.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportingChain", txn, email)
+	ret0, _ := ret[0].([]service.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportingChain indicates an expected call of ReportingChain
+func (mr *MockUserServiceMockRecorder) ReportingChain(txn, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportingChain", reflect.TypeOf((*MockUserService)(nil).ReportingChain), txn, email)
+}
+
+// OrgTree mocks base method
+func (m *MockUserService) OrgTree(txn *memdb.Txn, email string, maxDepth int) (service.OrgNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrgTree", txn, email, maxDepth)
+	ret0, _ := ret[0].(service.OrgNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrgTree indicates an expected call of OrgTree
+func (mr *MockUserServiceMockRecorder) OrgTree(txn, email, maxDepth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrgTree", reflect.TypeOf((*MockUserService)(nil).OrgTree), txn, email, maxDepth)
+}
+
+// SetPassword mocks base method
+func (m *MockUserService) SetPassword(txn *memdb.Txn, email, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", txn, email, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword
+func (mr *MockUserServiceMockRecorder) SetPassword(txn, email, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockUserService)(nil).SetPassword), txn, email, hash)
+}
+
+// Credential mocks base method
+func (m *MockUserService) Credential(txn *memdb.Txn, email string) (service.User, service.Credential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credential", txn, email)
+	ret0, _ := ret[0].(service.User)
+	ret1, _ := ret[1].(service.Credential)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Credential indicates an expected call of Credential
+func (mr *MockUserServiceMockRecorder) Credential(txn, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credential", reflect.TypeOf((*MockUserService)(nil).Credential), txn, email)
+}
+
+// RecordAuthentication mocks base method
+func (m *MockUserService) RecordAuthentication(txn *memdb.Txn, userID string, succeeded bool, lockout service.Lockout) (service.Credential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAuthentication", txn, userID, succeeded, lockout)
+	ret0, _ := ret[0].(service.Credential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordAuthentication indicates an expected call of RecordAuthentication
+func (mr *MockUserServiceMockRecorder) RecordAuthentication(txn, userID, succeeded, lockout interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserService)(nil).GetByEmail), txn, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAuthentication", reflect.TypeOf((*MockUserService)(nil).RecordAuthentication), txn, userID, succeeded, lockout)
 }
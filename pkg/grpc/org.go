@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+
+	service "github.com/maelvls/users-grpc/pkg/service"
+	pb "github.com/maelvls/users-grpc/schema/user"
+)
+
+// GetDirectReports returns the users that report to the given user.
+func (server *UserServer) GetDirectReports(ctx context.Context, req *pb.GetDirectReportsReq) (*pb.GetDirectReportsResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	users, err := server.Svc.DirectReports(txn, req.Email)
+	switch {
+	case err == service.EmailNotFound:
+		return &pb.GetDirectReportsResp{Status: emailNotFound(req.Email)}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("DirectReports returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while getting the direct reports of user, email=" + req.Email)
+	}
+
+	return &pb.GetDirectReportsResp{Users: ToPBs(users), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// GetReportingChain returns the managers of the given user up to the user
+// that has no manager.
+func (server *UserServer) GetReportingChain(ctx context.Context, req *pb.GetReportingChainReq) (*pb.GetReportingChainResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	users, err := server.Svc.ReportingChain(txn, req.Email)
+	switch {
+	case err == service.EmailNotFound:
+		return &pb.GetReportingChainResp{Status: emailNotFound(req.Email)}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("ReportingChain returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while getting the reporting chain of user, email=" + req.Email)
+	}
+
+	return &pb.GetReportingChainResp{Users: ToPBs(users), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// GetOrgTree returns the given user along with all the users below it, up
+// to max_depth levels.
+func (server *UserServer) GetOrgTree(ctx context.Context, req *pb.GetOrgTreeReq) (*pb.GetOrgTreeResp, error) {
+	if req.MaxDepth < 0 {
+		return &pb.GetOrgTreeResp{Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  "the max depth cannot be negative",
+		}}, nil
+	}
+
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	root, err := server.Svc.OrgTree(txn, req.Email, int(req.MaxDepth))
+	switch {
+	case err == service.EmailNotFound:
+		return &pb.GetOrgTreeResp{Status: emailNotFound(req.Email)}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("OrgTree returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while getting the org tree of user, email=" + req.Email)
+	}
+
+	return &pb.GetOrgTreeResp{Root: orgNodeToPB(root), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+func emailNotFound(email string) *pb.Status {
+	return &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: fmt.Sprintf("the email %s cannot be found", email)}
+}
+
+func orgNodeToPB(node service.OrgNode) *pb.OrgNode {
+	res := &pb.OrgNode{User: ToPB(node.User), Truncated: node.Truncated}
+	for _, report := range node.Reports {
+		res.Reports = append(res.Reports, orgNodeToPB(report))
+	}
+	return res
+}
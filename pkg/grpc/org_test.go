@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/maelvls/users-grpc/pkg/grpc/mocks"
+	service "github.com/maelvls/users-grpc/pkg/service"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	td "github.com/maxatome/go-testdeep"
+)
+
+func TestUserServer_GetOrgTree(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.GetOrgTreeReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.GetOrgTreeResp
+		wantErr   error
+	}{
+		{
+			name:     "when the user is found, it returns the tree below it",
+			givenReq: &pb.GetOrgTreeReq{Email: "ceo@pod.ru", MaxDepth: 1},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.OrgTree(someTxn(), "ceo@pod.ru", 1).Return(service.OrgNode{
+					User: service.User{ID: "a1", Email: "ceo@pod.ru"},
+					Reports: []service.OrgNode{
+						{User: service.User{ID: "a2", Email: "b@pod.ru", ManagerID: "a1"}, Truncated: true},
+					},
+				}, nil)
+			},
+			want: &pb.GetOrgTreeResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Root: &pb.OrgNode{
+					User: &pb.User{Id: "a1", Email: "ceo@pod.ru", Name: &pb.Name{}, Address: &pb.Address{}},
+					Reports: []*pb.OrgNode{
+						{User: &pb.User{Id: "a2", Email: "b@pod.ru", ManagerId: "a1", Name: &pb.Name{}, Address: &pb.Address{}}, Truncated: true},
+					},
+				},
+			},
+		},
+		{
+			name:     "when the user cannot be found, return an understandable message",
+			givenReq: &pb.GetOrgTreeReq{Email: "ceo@pod.ru"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.OrgTree(someTxn(), "ceo@pod.ru", 0).Return(service.OrgNode{}, service.EmailNotFound)
+			},
+			want: &pb.GetOrgTreeResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email ceo@pod.ru cannot be found"}},
+		},
+		{
+			name:      "when the max depth is negative, return an understandable message",
+			givenReq:  &pb.GetOrgTreeReq{Email: "ceo@pod.ru", MaxDepth: -1},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.GetOrgTreeResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the max depth cannot be negative"}},
+		},
+		{
+			name:     "unknown OrgTree errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.GetOrgTreeReq{Email: "ceo@pod.ru"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.OrgTree(someTxn(), "ceo@pod.ru", 0).Return(service.OrgNode{}, fmt.Errorf("some random error"))
+			},
+			wantErr: fmt.Errorf("something wrong happened while getting the org tree of user, email=ceo@pod.ru"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.GetOrgTree(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_GetReportingChain(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.GetReportingChainReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.GetReportingChainResp
+		wantErr   error
+	}{
+		{
+			name:     "when the user is found, it returns its managers",
+			givenReq: &pb.GetReportingChainReq{Email: "b@pod.ru"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.ReportingChain(someTxn(), "b@pod.ru").Return([]service.User{{ID: "a1", Email: "ceo@pod.ru"}}, nil)
+			},
+			want: &pb.GetReportingChainResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Users:  []*pb.User{{Id: "a1", Email: "ceo@pod.ru", Name: &pb.Name{}, Address: &pb.Address{}}},
+			},
+		},
+		{
+			name:     "when the user cannot be found, return an understandable message",
+			givenReq: &pb.GetReportingChainReq{Email: "b@pod.ru"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.ReportingChain(someTxn(), "b@pod.ru").Return(nil, service.EmailNotFound)
+			},
+			want: &pb.GetReportingChainResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email b@pod.ru cannot be found"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.GetReportingChain(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	context "golang.org/x/net/context"
+
+	service "github.com/maelvls/users-grpc/pkg/service"
+	pb "github.com/maelvls/users-grpc/schema/user"
+)
+
+// SetPassword sets or replaces the password of a user. The password is
+// hashed before the write transaction is opened since hashing is slow on
+// purpose.
+func (server *UserServer) SetPassword(ctx context.Context, req *pb.SetPasswordReq) (*pb.SetPasswordResp, error) {
+	hash, err := server.passwordHasher().Hash(req.Password)
+	switch {
+	case errors.Is(err, service.PasswordTooShort):
+		return &pb.SetPasswordResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: err.Error()}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("Hash returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while setting the password of user, email=" + req.Email)
+	}
+
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	err = server.Svc.SetPassword(txn, req.Email, hash)
+	switch {
+	case err == service.EmailNotFound:
+		return &pb.SetPasswordResp{Status: emailNotFound(req.Email)}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("SetPassword returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while setting the password of user, email=" + req.Email)
+	}
+	server.Commit(txn)
+
+	return &pb.SetPasswordResp{Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// Authenticate checks the password of a user. In order not to tell which
+// emails exist, an unknown email or a user without password fails the
+// same way as a wrong password, and a password is still verified against
+// a dummy hash so that it takes as long.
+//
+// A locked account also fails the same way, whatever the password, so
+// that the lockout tells neither that the email exists nor whether the
+// password was right. Its password is still verified so that it takes as
+// long, and each failure extends the lockout.
+//
+// The password is verified outside of any transaction; the result is then
+// recorded in a write transaction.
+func (server *UserServer) Authenticate(ctx context.Context, req *pb.AuthenticateReq) (*pb.AuthenticateResp, error) {
+	txn := server.Txn(false)
+	user, cred, err := server.Svc.Credential(txn, req.Email)
+	server.Rollback(txn)
+	switch {
+	case err == service.EmailNotFound:
+		// Handled below like a user without password.
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("Credential returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while authenticating user, email=" + req.Email)
+	}
+
+	if cred.Hash == "" {
+		service.VerifyPassword(server.getDummyHash(), req.Password)
+		return &pb.AuthenticateResp{Status: invalidCredentials()}, nil
+	}
+	succeeded := service.VerifyPassword(cred.Hash, req.Password)
+
+	txn = server.Txn(true)
+	defer server.Rollback(txn)
+
+	cred, err = server.Svc.RecordAuthentication(txn, user.ID, succeeded, server.Lockout)
+	switch {
+	case err == service.AccountLocked:
+		// The failures made while the account is locked extend the
+		// lockout, which means that they must be committed too.
+		server.Commit(txn)
+		logrus.WithField("email", req.Email).Warn("authentication refused since the account is locked")
+		return &pb.AuthenticateResp{Status: invalidCredentials()}, nil
+	case err == service.InvalidCredentials:
+		return &pb.AuthenticateResp{Status: invalidCredentials()}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("RecordAuthentication returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while authenticating user, email=" + req.Email)
+	}
+	server.Commit(txn)
+
+	if !succeeded {
+		return &pb.AuthenticateResp{Status: invalidCredentials()}, nil
+	}
+
+	return &pb.AuthenticateResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+func (server *UserServer) passwordHasher() service.PasswordHasher {
+	if server.PasswordHasher == (service.PasswordHasher{}) {
+		return service.DefaultPasswordHasher
+	}
+	return server.PasswordHasher
+}
+
+// getDummyHash returns the hash used when the user has no password. It is
+// created once with the same parameters as the real hashes.
+func (server *UserServer) getDummyHash() string {
+	server.dummyHashOnce.Do(func() {
+		server.dummyHash = server.passwordHasher().DummyHash()
+	})
+	return server.dummyHash
+}
+
+func invalidCredentials() *pb.Status {
+	return &pb.Status{Code: pb.Status_FAILED, Msg: service.InvalidCredentials.Error()}
+}
@@ -0,0 +1,224 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/maelvls/users-grpc/pkg/grpc/mocks"
+	service "github.com/maelvls/users-grpc/pkg/service"
+	pb "github.com/maelvls/users-grpc/schema/user"
+	td "github.com/maxatome/go-testdeep"
+)
+
+// Cheap parameters so that the tests stay fast.
+var testHasher = service.PasswordHasher{Time: 1, Memory: 64, Threads: 1}
+
+func TestUserServer_SetPassword(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.SetPasswordReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.SetPasswordResp
+		wantErr   error
+	}{
+		{
+			name:     "when the user is found, the hash of the password is stored",
+			givenReq: &pb.SetPasswordReq{Email: "ceo@pod.ru", Password: "correct horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SetPassword(someTxn(), "ceo@pod.ru", hashOf("correct horse")).Return(nil)
+			},
+			want: &pb.SetPasswordResp{Status: &pb.Status{Code: pb.Status_SUCCESS}},
+		},
+		{
+			name:      "when the password is too short, return an understandable message",
+			givenReq:  &pb.SetPasswordReq{Email: "ceo@pod.ru", Password: "horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want: &pb.SetPasswordResp{Status: &pb.Status{
+				Code: pb.Status_INVALID_QUERY,
+				Msg:  "the password is too short: it must be at least 8 characters long",
+			}},
+		},
+		{
+			name:     "when the user cannot be found, return an understandable message",
+			givenReq: &pb.SetPasswordReq{Email: "ceo@pod.ru", Password: "correct horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SetPassword(someTxn(), "ceo@pod.ru", gomock.Any()).Return(service.EmailNotFound)
+			},
+			want: &pb.SetPasswordResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email ceo@pod.ru cannot be found"}},
+		},
+		{
+			name:     "unknown SetPassword errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.SetPasswordReq{Email: "ceo@pod.ru", Password: "correct horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SetPassword(someTxn(), "ceo@pod.ru", gomock.Any()).Return(fmt.Errorf("some random error"))
+			},
+			wantErr: fmt.Errorf("something wrong happened while setting the password of user, email=ceo@pod.ru"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:            func(b bool) *memdb.Txn { return nil },
+				Commit:         func(m *memdb.Txn) {},
+				Rollback:       func(m *memdb.Txn) {},
+				Svc:            mockUserSvc,
+				PasswordHasher: testHasher,
+			}
+
+			got, gotErr := svc.SetPassword(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_Authenticate(t *testing.T) {
+	hash, err := testHasher.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := service.User{ID: "a1", Email: "ceo@pod.ru"}
+	lockout := service.Lockout{MaxAttempts: 3, Duration: time.Minute}
+	now := time.Date(2020, 6, 2, 8, 30, 0, 0, time.UTC)
+	locked := service.Credential{UserID: "a1", Hash: hash, LockedUntil: now.Add(time.Minute)}
+	invalid := &pb.AuthenticateResp{Status: &pb.Status{Code: pb.Status_FAILED, Msg: "invalid email or password"}}
+
+	tests := []struct {
+		name      string
+		givenReq  *pb.AuthenticateReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.AuthenticateResp
+		wantErr   error
+	}{
+		{
+			name:     "when the password matches, the user is returned",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: "correct horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(user, service.Credential{UserID: "a1", Hash: hash}, nil)
+				rec.RecordAuthentication(someTxn(), "a1", true, lockout).Return(service.Credential{UserID: "a1", Hash: hash}, nil)
+			},
+			want: &pb.AuthenticateResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				User:   &pb.User{Id: "a1", Email: "ceo@pod.ru", Name: &pb.Name{}, Address: &pb.Address{}},
+			},
+		},
+		{
+			name:     "when the password is wrong, the failure is recorded",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: "wrong horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(user, service.Credential{UserID: "a1", Hash: hash}, nil)
+				rec.RecordAuthentication(someTxn(), "a1", false, lockout).Return(service.Credential{UserID: "a1", Hash: hash, FailedAttempts: 1}, nil)
+			},
+			want: invalid,
+		},
+		{
+			name:     "when the failure locks the account, fail the same way as a wrong password",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: "wrong horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(user, service.Credential{UserID: "a1", Hash: hash, FailedAttempts: 2}, nil)
+				rec.RecordAuthentication(someTxn(), "a1", false, lockout).Return(locked, service.AccountLocked)
+			},
+			want: invalid,
+		},
+		{
+			name:     "when the account is locked, the right password fails the same way as a wrong one",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: "correct horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(user, locked, nil)
+				rec.RecordAuthentication(someTxn(), "a1", true, lockout).Return(locked, service.AccountLocked)
+			},
+			want: invalid,
+		},
+		{
+			name:     "when the account is locked, a wrong password is recorded and fails",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: "wrong horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(user, locked, nil)
+				rec.RecordAuthentication(someTxn(), "a1", false, lockout).Return(locked, service.AccountLocked)
+			},
+			want: invalid,
+		},
+		{
+			name:     "when the email is unknown, fail the same way as a wrong password",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: "correct horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(service.User{}, service.Credential{}, service.EmailNotFound)
+			},
+			want: invalid,
+		},
+		{
+			name:     "when the user has no password, fail the same way as a wrong password",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: ""},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(user, service.Credential{}, nil)
+			},
+			want: invalid,
+		},
+		{
+			name:     "unknown Credential errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.AuthenticateReq{Email: "ceo@pod.ru", Password: "correct horse"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Credential(someTxn(), "ceo@pod.ru").Return(service.User{}, service.Credential{}, fmt.Errorf("some random error"))
+			},
+			wantErr: fmt.Errorf("something wrong happened while authenticating user, email=ceo@pod.ru"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:            func(b bool) *memdb.Txn { return nil },
+				Commit:         func(m *memdb.Txn) {},
+				Rollback:       func(m *memdb.Txn) {},
+				Svc:            mockUserSvc,
+				PasswordHasher: testHasher,
+				Lockout:        lockout,
+			}
+
+			got, gotErr := svc.Authenticate(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+// hashOf matches the hashes of the given password, whatever their salt.
+func hashOf(password string) gomock.Matcher {
+	return passwordMatcher(password)
+}
+
+type passwordMatcher string
+
+func (m passwordMatcher) Matches(x interface{}) bool {
+	hash, ok := x.(string)
+	return ok && service.VerifyPassword(hash, string(m))
+}
+
+func (m passwordMatcher) String() string {
+	return "is a hash of " + string(m)
+}
@@ -28,15 +28,20 @@ import (
 
 // Run starts the server. Set reflexion to true if you want to be able to
 // use grpcurl or prototool to discover the proto files.
-func Run(ctx context.Context, addr, addrMetrics string, enableReflection, tls, samples bool, certFile, keyFile string) error {
+func Run(ctx context.Context, addr, addrMetrics string, enableReflection, tls, samples bool, certFile, keyFile string, maxBatchGet int, hasher service.PasswordHasher, lockout service.Lockout) error {
 	userServer := NewUserServer()
+	userServer.MaxBatchGet = maxBatchGet
+	userServer.PasswordHasher = hasher
+	userServer.Lockout = lockout
 
 	if samples {
 		logrus.Info("loading sample users, disable with --samples=false")
 
 		txn := userServer.Txn(true)
 		defer userServer.Rollback(txn)
-		err := service.LoadSampleUsers(txn)
+		// The samples are created with the clock of the service.
+		svc, _ := userServer.Svc.(service.UserSvc)
+		err := svc.LoadSampleUsers(txn)
 		if err != nil {
 			return fmt.Errorf("while loading sample users: %w", err)
 		}
@@ -66,9 +71,14 @@ func Run(ctx context.Context, addr, addrMetrics string, enableReflection, tls, s
 		grpc_prometheus.UnaryServerInterceptor,
 		grpc_logrus.UnaryServerInterceptor(logrus.NewEntry(logrus.New()), grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel)),
 	)))
+	opts = append(opts, grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+		grpc_prometheus.StreamServerInterceptor,
+		grpc_logrus.StreamServerInterceptor(logrus.NewEntry(logrus.New()), grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel)),
+	)))
 
 	srv := grpc.NewServer(opts...)
 	user.RegisterUserServiceServer(srv, userServer)
+	user.RegisterGroupServiceServer(srv, NewGroupServer(userServer))
 	health := health.NewServer()
 	health.SetServingStatus("user", grpc_health_v1.HealthCheckResponse_SERVING)
 	grpc_health_v1.RegisterHealthServer(srv, health)
@@ -1,11 +1,17 @@
 package grpc
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"sync"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/sirupsen/logrus"
 	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	service "github.com/maelvls/users-grpc/pkg/service"
 	pb "github.com/maelvls/users-grpc/schema/user"
@@ -14,10 +20,30 @@ import (
 // For testing purposes.
 type UserService interface {
 	Create(*memdb.Txn, service.User) error
-	List(*memdb.Txn) ([]service.User, error)
-	SearchAge(txn *memdb.Txn, ageFrom, ageTo int32) ([]service.User, error)
-	SearchName(txn *memdb.Txn, query string) ([]service.User, error)
-	GetByEmail(txn *memdb.Txn, email string) (service.User, error)
+	List(txn *memdb.Txn, includeDeleted bool, created service.TimeRange, selector service.Selector, page service.Page) ([]service.User, string, error)
+	SearchAge(txn *memdb.Txn, ageFrom, ageTo int32, page service.Page) ([]service.User, string, error)
+	SearchName(txn *memdb.Txn, query string, page service.Page) ([]service.User, string, error)
+	SearchNameFuzzy(txn *memdb.Txn, query string, maxDistance *int32, page service.Page) ([]service.Match, string, error)
+	SearchNamePhonetic(txn *memdb.Txn, query string, page service.Page) ([]service.User, string, error)
+	Search(txn *memdb.Txn, query service.SearchQuery, page service.Page) ([]service.User, string, error)
+	StreamList(txn *memdb.Txn, includeDeleted bool, created service.TimeRange, selector service.Selector, fn func(service.User) error) error
+	StreamSearchAge(txn *memdb.Txn, ageFrom, ageTo int32, fn func(service.User) error) error
+	StreamSearchName(txn *memdb.Txn, query string, fn func(service.User) error) error
+	GetByEmail(txn *memdb.Txn, email string, includeDeleted bool) (service.User, error)
+	GetByID(txn *memdb.Txn, id string) (service.User, error)
+	GetByPhone(txn *memdb.Txn, phone string) (service.User, error)
+	Update(txn *memdb.Txn, email string, fields []string, user service.User, etag string) (service.User, error)
+	Delete(txn *memdb.Txn, email, id, etag string) (service.User, error)
+	ChangeEmail(txn *memdb.Txn, oldEmail, newEmail, etag string) (service.User, error)
+	Restore(txn *memdb.Txn, email, etag string) (service.User, error)
+	Purge(txn *memdb.Txn, email, etag string) (service.User, error)
+	Stats(txn *memdb.Txn, ageBuckets []int32) (service.Stats, error)
+	DirectReports(txn *memdb.Txn, email string) ([]service.User, error)
+	ReportingChain(txn *memdb.Txn, email string) ([]service.User, error)
+	OrgTree(txn *memdb.Txn, email string, maxDepth int) (service.OrgNode, error)
+	SetPassword(txn *memdb.Txn, email, hash string) error
+	Credential(txn *memdb.Txn, email string) (service.User, service.Credential, error)
+	RecordAuthentication(txn *memdb.Txn, userID string, succeeded bool, lockout service.Lockout) (service.Credential, error)
 }
 
 // UserServer implements the GRPC endpoints of the "user" service. If I
@@ -28,8 +54,21 @@ type UserServer struct {
 	Commit   func(*memdb.Txn)
 	Rollback func(*memdb.Txn)
 
+	// Maximum number of emails and ids given to BatchGet. When 0,
+	// DefaultMaxBatchGet is used.
+	MaxBatchGet int
+
+	// Used by SetPassword and Authenticate. When PasswordHasher is zero,
+	// service.DefaultPasswordHasher is used. A zero Lockout never locks
+	// the accounts.
+	PasswordHasher service.PasswordHasher
+	Lockout        service.Lockout
+
 	// For testing purposes.
 	Svc UserService
+
+	dummyHash     string
+	dummyHashOnce sync.Once
 }
 
 // NewUserServer returns a new server.
@@ -40,7 +79,8 @@ func NewUserServer() *UserServer {
 		Txn:      db.Txn,
 		Commit:   func(m *memdb.Txn) { m.Commit() },
 		Rollback: func(m *memdb.Txn) { m.Abort() },
-		Svc:      service.UserSvc{},
+		Svc:      service.UserSvc{Clock: time.Now},
+		Lockout:  service.DefaultLockout,
 	}
 }
 
@@ -51,15 +91,15 @@ func (server *UserServer) Create(ctx context.Context, req *pb.CreateReq) (*pb.Cr
 	defer server.Rollback(txn)
 
 	err := server.Svc.Create(txn, FromPB(req.User))
-	switch {
-	case err == service.EmailAlreadyExists:
-		return &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_FAILED, Msg: err.Error()}}, nil
-	case err != nil:
+	if status := createFailure(err, req.User); status != nil {
+		return &pb.CreateResp{User: &pb.User{}, Status: status}, nil
+	}
+	if err != nil {
 		logrus.WithError(err).WithField("email", req.User.Email).Error("Create returned an unexpected error")
 		return nil, fmt.Errorf("something wrong happened while creating user, email=" + req.User.Email)
 	}
 
-	user, err := server.Svc.GetByEmail(txn, req.User.Email)
+	user, err := server.Svc.GetByEmail(txn, req.User.Email, false)
 	if err != nil {
 		logrus.WithError(err).Error("GetByEmail returned an unexpected error")
 		return nil, fmt.Errorf("something wrong happened while finding the user, email=" + req.User.Email)
@@ -70,18 +110,156 @@ func (server *UserServer) Create(ctx context.Context, req *pb.CreateReq) (*pb.Cr
 	return &pb.CreateResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
 }
 
+// createFailure returns the status of a Create that failed because of the
+// given user, e.g. because its email is already used. It returns nil when
+// the creation succeeded or when the error is unexpected.
+func createFailure(err error, u *pb.User) *pb.Status {
+	switch {
+	case err == service.EmailAlreadyExists, err == service.IDAlreadyExists, err == service.PhoneAlreadyExists:
+		return &pb.Status{Code: pb.Status_FAILED, Msg: err.Error()}
+	case err == service.EmailEmpty:
+		return &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email cannot be empty"}
+	case err == service.EmailInvalid:
+		return &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: fmt.Sprintf("the email '%s' is invalid", u.Email)}
+	case err == service.PhoneInvalid:
+		return &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: fmt.Sprintf("the phone '%s' is invalid", u.Phone)}
+	case errors.Is(err, service.LabelInvalid), err == service.ManagerCycle:
+		return &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: err.Error()}
+	case err == service.ManagerNotFound:
+		return &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: fmt.Sprintf("the manager %s cannot be found", u.ManagerId)}
+	}
+	return nil
+}
+
+// When BulkCreateReq.batch_size is not given, the users are committed by
+// batches of this size.
+const defaultBulkBatchSize = 100
+
+// BulkCreate creates the users received on the stream. The users are
+// buffered and each batch is created in its own write transaction, which
+// means that the database is not locked while waiting for the client.
+func (server *UserServer) BulkCreate(stream pb.UserService_BulkCreateServer) error {
+	logrus.Info("bulk create request received")
+
+	resp := &pb.BulkCreateResp{}
+	batchSize := 0
+	var batch []*pb.User
+	first := 0 // Index of the first user of the batch in the stream.
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			batchSize = int(req.BatchSize)
+			if batchSize < 0 {
+				return stream.SendAndClose(&pb.BulkCreateResp{Status: &pb.Status{
+					Code: pb.Status_INVALID_QUERY,
+					Msg:  "the batch size cannot be negative",
+				}})
+			}
+			if batchSize == 0 {
+				batchSize = defaultBulkBatchSize
+			}
+		}
+
+		batch = append(batch, req.User)
+		if len(batch) == batchSize {
+			err = server.createBatch(first, batch, resp)
+			if err != nil {
+				return err
+			}
+			first, batch = i+1, batch[:0]
+		}
+	}
+
+	err := server.createBatch(first, batch, resp)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(resp.Failures) == 0:
+		resp.Status = &pb.Status{Code: pb.Status_SUCCESS}
+	case resp.Created == 0:
+		resp.Status = &pb.Status{Code: pb.Status_FAILED, Msg: fmt.Sprintf("none of the %d users could be created", len(resp.Failures))}
+	default:
+		resp.Status = &pb.Status{Code: pb.Status_PARTIAL_SUCCESS, Msg: fmt.Sprintf("%d users created, %d rejected", resp.Created, len(resp.Failures))}
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// createBatch creates the users in a single transaction and adds the
+// users that were rejected to the failures of the response. The first
+// user of the batch is at the given index in the stream.
+func (server *UserServer) createBatch(first int, users []*pb.User, resp *pb.BulkCreateResp) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	created := int32(0)
+	for i, u := range users {
+		if u == nil {
+			resp.Failures = append(resp.Failures, &pb.BulkCreateFailure{Index: int32(first + i), Status: &pb.Status{
+				Code: pb.Status_INVALID_QUERY,
+				Msg:  "the user object cannot be omitted",
+			}})
+			continue
+		}
+
+		err := server.Svc.Create(txn, FromPB(u))
+		if status := createFailure(err, u); status != nil {
+			resp.Failures = append(resp.Failures, &pb.BulkCreateFailure{Index: int32(first + i), Email: u.Email, Status: status})
+			continue
+		}
+		if err != nil {
+			logrus.WithError(err).WithField("email", u.Email).Error("Create returned an unexpected error")
+			return fmt.Errorf("something wrong happened while creating users, email=" + u.Email)
+		}
+		created++
+	}
+
+	server.Commit(txn)
+	resp.Created += created
+
+	return nil
+}
+
 // List all users.
 func (server *UserServer) List(ctx context.Context, req *pb.ListReq) (*pb.SearchResp, error) {
 	txn := server.Txn(false) // read-only transaction
 	defer server.Rollback(txn)
 
-	users, err := server.Svc.List(txn)
+	selector, err := service.ParseSelector(req.Selector)
 	if err != nil {
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
+	}
+
+	created := FromPBTimeRange(req.CreatedAfter, req.CreatedBefore)
+	users, next, err := server.Svc.List(txn, req.IncludeDeleted, created, selector, FromPBPage(req.PageSize, req.PageToken, req.OrderBy))
+	switch {
+	case err == service.PageSizeNegative, err == service.InvalidPageToken, err == service.OrderByInvalid, err == service.TimeRangeInvalid:
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
+	case err != nil:
 		logrus.WithError(err).Error("List returned an unexpected error")
 		return nil, fmt.Errorf("something wrong happened while listing users")
 	}
 
-	resp := &pb.SearchResp{Users: ToPBs(users), Status: &pb.Status{Code: pb.Status_SUCCESS}}
+	resp := &pb.SearchResp{Users: ToPBs(users), NextPageToken: next, Status: &pb.Status{Code: pb.Status_SUCCESS}}
 	return resp, nil
 }
 
@@ -97,7 +275,7 @@ func (server *UserServer) SearchAge(ctx context.Context, req *pb.SearchAgeReq) (
 	txn := server.Txn(false)
 	defer server.Rollback(txn)
 
-	users, err := server.Svc.SearchAge(txn, req.AgeRange.From, req.AgeRange.ToIncluded)
+	users, next, err := server.Svc.SearchAge(txn, req.AgeRange.From, req.AgeRange.ToIncluded, FromPBPage(req.PageSize, req.PageToken, req.OrderBy))
 
 	switch {
 	case err == service.AgeFromIsGreaterThanAgeTo:
@@ -105,33 +283,234 @@ func (server *UserServer) SearchAge(ctx context.Context, req *pb.SearchAgeReq) (
 			Code: pb.Status_INVALID_QUERY,
 			Msg:  "age is invalid, the 'from' age must be lower or equal to the 'to' age",
 		}}, nil
+	case err == service.PageSizeNegative, err == service.InvalidPageToken, err == service.OrderByInvalid:
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
 	case err != nil:
 		logrus.WithError(err).Error("SearchAge returned an unexpected error")
 		return nil, fmt.Errorf("something wrong happened while searching users with their age")
 	}
 
-	resp := &pb.SearchResp{Users: ToPBs(users), Status: &pb.Status{Code: pb.Status_SUCCESS}}
+	resp := &pb.SearchResp{Users: ToPBs(users), NextPageToken: next, Status: &pb.Status{Code: pb.Status_SUCCESS}}
 	return resp, nil
 }
 
-// SearchName searches a user by a part of its first or last name.
+// SearchName searches a user by a part of its first or last name using
+// the given match mode. In fuzzy mode, the users are returned along with
+// their scores.
 func (server *UserServer) SearchName(ctx context.Context, req *pb.SearchNameReq) (*pb.SearchResp, error) {
 	txn := server.Txn(false)
 	defer server.Rollback(txn)
 
-	users, err := server.Svc.SearchName(txn, req.Query)
+	var (
+		users  []service.User
+		scores []float64
+		next   string
+		err    error
+	)
+	page := FromPBPage(req.PageSize, req.PageToken, req.OrderBy)
+	switch req.MatchMode {
+	case pb.SearchNameReq_SUBSTRING:
+		users, next, err = server.Svc.SearchName(txn, req.Query, page)
+	case pb.SearchNameReq_FUZZY:
+		var maxDistance *int32
+		if req.MaxDistance != nil {
+			d := req.MaxDistance.Value
+			maxDistance = &d
+		}
+		var matches []service.Match
+		matches, next, err = server.Svc.SearchNameFuzzy(txn, req.Query, maxDistance, page)
+		for _, m := range matches {
+			users = append(users, m.User)
+			scores = append(scores, m.Score)
+		}
+	case pb.SearchNameReq_PHONETIC:
+		users, next, err = server.Svc.SearchNamePhonetic(txn, req.Query, page)
+	default:
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("unknown match mode %d", req.MatchMode),
+		}}, nil
+	}
+
 	switch {
 	case err == service.NameQueryEmpty:
 		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
 			Code: pb.Status_INVALID_QUERY,
 			Msg:  "name query cannot be empty",
 		}}, nil
+	case err == service.MaxDistanceNegative, err == service.PageSizeNegative, err == service.InvalidPageToken, err == service.OrderByInvalid:
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
 	case err != nil:
 		logrus.WithError(err).WithField("query", req.Query).Error("SearchName returned an unexpected error")
 		return nil, fmt.Errorf("something wrong happened while finding users by name, query=" + req.Query)
 	}
 
-	return &pb.SearchResp{Users: ToPBs(users), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+	return &pb.SearchResp{Users: ToPBs(users), Scores: scores, NextPageToken: next, Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// Search searches the users matching all the given criteria.
+func (server *UserServer) Search(ctx context.Context, req *pb.SearchReq) (*pb.SearchResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	query := FromPBSearch(req)
+	var err error
+	query.Selector, err = service.ParseSelector(req.Selector)
+	if err != nil {
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
+	}
+
+	users, next, err := server.Svc.Search(txn, query, FromPBPage(req.PageSize, req.PageToken, req.OrderBy))
+	switch {
+	case err == service.AgeFromIsGreaterThanAgeTo:
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  "age is invalid, the 'from' age must be lower or equal to the 'to' age",
+		}}, nil
+	case err == service.PhoneQueryInvalid, err == service.PageSizeNegative, err == service.InvalidPageToken, err == service.OrderByInvalid, err == service.TimeRangeInvalid:
+		return &pb.SearchResp{Users: make([]*pb.User, 0), Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).Error("Search returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while searching users")
+	}
+
+	return &pb.SearchResp{Users: ToPBs(users), NextPageToken: next, Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// Stats returns the statistics of the users that are not deleted.
+func (server *UserServer) Stats(ctx context.Context, req *pb.StatsReq) (*pb.StatsResp, error) {
+	buckets := req.AgeBuckets
+	if len(buckets) == 0 {
+		buckets = service.DefaultAgeBuckets
+	}
+
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	stats, err := server.Svc.Stats(txn, buckets)
+	switch {
+	case err == service.AgeBucketsInvalid:
+		return &pb.StatsResp{Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the age bucket bounds %v must be positive and increasing", req.AgeBuckets),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).Error("Stats returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while computing the statistics")
+	}
+
+	resp := &pb.StatsResp{
+		Status:    &pb.Status{Code: pb.Status_SUCCESS},
+		Total:     int32(stats.Total),
+		MinAge:    stats.MinAge,
+		MaxAge:    stats.MaxAge,
+		MeanAge:   stats.MeanAge,
+		MedianAge: stats.MedianAge,
+	}
+	for _, b := range stats.AgeBuckets {
+		resp.AgeBuckets = append(resp.AgeBuckets, &pb.AgeBucket{From: b.From, To: b.To, Count: int32(b.Count)})
+	}
+	resp.EmailDomains = countsToPB(stats.EmailDomains)
+	resp.Regions = countsToPB(stats.Regions)
+
+	return resp, nil
+}
+
+func countsToPB(counts []service.Count) []*pb.Count {
+	var res []*pb.Count
+	for _, c := range counts {
+		res = append(res, &pb.Count{Key: c.Key, Count: int32(c.Count)})
+	}
+	return res
+}
+
+// StreamList sends the users one by one, filtered the same way as with
+// List. The stream stops early when the client goes away.
+func (server *UserServer) StreamList(req *pb.StreamListReq, stream pb.UserService_StreamListServer) error {
+	invalid := func(msg string) error {
+		return stream.Send(&pb.StreamResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: msg}})
+	}
+
+	selector, err := service.ParseSelector(req.Selector)
+	if err != nil {
+		return invalid(err.Error())
+	}
+
+	txn := server.Txn(false) // read-only transaction
+	defer server.Rollback(txn)
+
+	created := FromPBTimeRange(req.CreatedAfter, req.CreatedBefore)
+	err = server.Svc.StreamList(txn, req.IncludeDeleted, created, selector, sendUser(stream))
+	switch {
+	case err == nil:
+		return nil
+	case err == service.TimeRangeInvalid:
+		return invalid(err.Error())
+	case stream.Context().Err() != nil:
+		return stream.Context().Err()
+	default:
+		logrus.WithError(err).Error("StreamList returned an unexpected error")
+		return fmt.Errorf("something wrong happened while listing users")
+	}
+}
+
+// StreamSearch sends the users matching either the name or the age range
+// one by one. The stream stops early when the client goes away.
+func (server *UserServer) StreamSearch(req *pb.StreamSearchReq, stream pb.UserService_StreamSearchServer) error {
+	invalid := func(msg string) error {
+		return stream.Send(&pb.StreamResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: msg}})
+	}
+
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	var err error
+	switch {
+	case req.Name != "" && req.AgeRange != nil:
+		return invalid("cannot search by age and by name at the same time")
+	case req.Name != "":
+		err = server.Svc.StreamSearchName(txn, req.Name, sendUser(stream))
+	case req.AgeRange != nil:
+		err = server.Svc.StreamSearchAge(txn, req.AgeRange.From, req.AgeRange.ToIncluded, sendUser(stream))
+	default:
+		return invalid("one of name or ageRange must be given")
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case err == service.AgeFromIsGreaterThanAgeTo:
+		return invalid("age is invalid, the 'from' age must be lower or equal to the 'to' age")
+	case stream.Context().Err() != nil:
+		return stream.Context().Err()
+	default:
+		logrus.WithError(err).WithField("name", req.Name).Error("StreamSearch returned an unexpected error")
+		return fmt.Errorf("something wrong happened while searching users")
+	}
+}
+
+// sendUser returns a function that sends each user to the stream. The
+// returned function fails as soon as the client's context is done so
+// that we stop walking the database.
+func sendUser(stream grpc.ServerStream) func(service.User) error {
+	return func(user service.User) error {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+		return stream.SendMsg(&pb.StreamResp{User: ToPB(user)})
+	}
 }
 
 // GetByEmail returns a user by its email.
@@ -139,7 +518,7 @@ func (server *UserServer) GetByEmail(ctx context.Context, req *pb.GetByEmailReq)
 	txn := server.Txn(false)
 	defer server.Rollback(txn)
 
-	user, err := server.Svc.GetByEmail(txn, req.Email)
+	user, err := server.Svc.GetByEmail(txn, req.Email, req.IncludeDeleted)
 	switch {
 	case err == service.EmailNotFound:
 		return &pb.GetByEmailResp{User: &pb.User{}, Status: &pb.Status{
@@ -155,27 +534,441 @@ func (server *UserServer) GetByEmail(ctx context.Context, req *pb.GetByEmailReq)
 	return resp, nil
 }
 
+// GetByID returns a user by its id.
+func (server *UserServer) GetByID(ctx context.Context, req *pb.GetByIDReq) (*pb.GetByIDResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	user, err := server.Svc.GetByID(txn, req.Id)
+	switch {
+	case err == service.IDNotFound:
+		return &pb.GetByIDResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the id %s cannot be found", req.Id),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("id", req.Id).Error("GetByID returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while getting a user by its id, id=" + req.Id)
+	}
+
+	resp := &pb.GetByIDResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}
+	return resp, nil
+}
+
+// GetByPhone returns a user by its phone number, whatever the way the
+// phone is formatted.
+func (server *UserServer) GetByPhone(ctx context.Context, req *pb.GetByPhoneReq) (*pb.GetByPhoneResp, error) {
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	user, err := server.Svc.GetByPhone(txn, req.Phone)
+	switch {
+	case err == service.PhoneNotFound:
+		return &pb.GetByPhoneResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the phone %s cannot be found", req.Phone),
+		}}, nil
+	case err == service.PhoneInvalid:
+		return &pb.GetByPhoneResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the phone '%s' is invalid", req.Phone),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("phone", req.Phone).Error("GetByPhone returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while getting a user by its phone, phone=" + req.Phone)
+	}
+
+	resp := &pb.GetByPhoneResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}
+	return resp, nil
+}
+
+// DefaultMaxBatchGet is the number of keys that BatchGet accepts when
+// UserServer.MaxBatchGet is not set.
+const DefaultMaxBatchGet = 100
+
+// BatchGet returns the users that have the given emails or ids. All the
+// users are read in the same read-only transaction.
+func (server *UserServer) BatchGet(ctx context.Context, req *pb.BatchGetReq) (*pb.BatchGetResp, error) {
+	limit := server.MaxBatchGet
+	if limit == 0 {
+		limit = DefaultMaxBatchGet
+	}
+	if n := len(req.Emails) + len(req.Ids); n > limit {
+		return &pb.BatchGetResp{Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("at most %d emails and ids can be given at once, got %d", limit, n),
+		}}, nil
+	}
+
+	txn := server.Txn(false)
+	defer server.Rollback(txn)
+
+	resp := &pb.BatchGetResp{}
+	seen := make(map[string]bool) // The ids of the users already returned.
+	add := func(user service.User) {
+		if seen[user.ID] {
+			return
+		}
+		seen[user.ID] = true
+		resp.Users = append(resp.Users, ToPB(user))
+	}
+
+	for _, email := range req.Emails {
+		user, err := server.Svc.GetByEmail(txn, email, false)
+		switch {
+		case err == service.EmailNotFound:
+			resp.MissingEmails = append(resp.MissingEmails, email)
+		case err != nil:
+			logrus.WithError(err).WithField("email", email).Error("GetByEmail returned an unexpected error")
+			return nil, fmt.Errorf("something wrong happened while getting users by their emails, email=" + email)
+		default:
+			add(user)
+		}
+	}
+
+	for _, id := range req.Ids {
+		user, err := server.Svc.GetByID(txn, id)
+		switch {
+		case err == service.IDNotFound:
+			resp.MissingIds = append(resp.MissingIds, id)
+		case err != nil:
+			logrus.WithError(err).WithField("id", id).Error("GetByID returned an unexpected error")
+			return nil, fmt.Errorf("something wrong happened while getting users by their ids, id=" + id)
+		default:
+			add(user)
+		}
+	}
+
+	resp.Status = &pb.Status{Code: pb.Status_SUCCESS}
+	return resp, nil
+}
+
+// Update changes the fields of a user that are listed in the update mask.
+func (server *UserServer) Update(ctx context.Context, req *pb.UpdateReq) (*pb.UpdateResp, error) {
+	logrus.WithField("email", req.Email).Info("update request received")
+	if req.User == nil {
+		return &pb.UpdateResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  "the user object cannot be omitted",
+		}}, nil
+	}
+
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	user, err := server.Svc.Update(txn, req.Email, req.UpdateMask.GetPaths(), FromPB(req.User), req.Etag)
+	switch {
+	case err == service.EtagInvalid:
+		return &pb.UpdateResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the etag '%s' is invalid", req.Etag),
+		}}, nil
+	case err == service.VersionMismatch:
+		return &pb.UpdateResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_CONFLICT,
+			Msg:  fmt.Sprintf("the user %s was modified since the etag %s was read", req.Email, req.Etag),
+		}}, nil
+	case err == service.EmailNotFound:
+		return &pb.UpdateResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the email %s cannot be found", req.Email),
+		}}, nil
+	case err == service.UpdateMaskEmpty, errors.Is(err, service.UpdateFieldUnknown), err == service.PhoneInvalid, errors.Is(err, service.LabelInvalid), err == service.ManagerCycle:
+		return &pb.UpdateResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
+	case err == service.ManagerNotFound:
+		return &pb.UpdateResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the manager %s cannot be found", req.User.ManagerId),
+		}}, nil
+	case err == service.PhoneAlreadyExists:
+		return &pb.UpdateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_FAILED, Msg: err.Error()}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("Update returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while updating user, email=" + req.Email)
+	}
+
+	server.Commit(txn)
+
+	return &pb.UpdateResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// Delete removes a user by its email or by its id.
+func (server *UserServer) Delete(ctx context.Context, req *pb.DeleteReq) (*pb.DeleteResp, error) {
+	logrus.WithField("email", req.Email).WithField("id", req.Id).Info("delete request received")
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	user, err := server.Svc.Delete(txn, req.Email, req.Id, req.Etag)
+	switch {
+	case err == service.EtagInvalid:
+		return &pb.DeleteResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the etag '%s' is invalid", req.Etag),
+		}}, nil
+	case err == service.VersionMismatch:
+		return &pb.DeleteResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_CONFLICT,
+			Msg:  fmt.Sprintf("the user was modified since the etag %s was read", req.Etag),
+		}}, nil
+	case err == service.EmailNotFound:
+		return &pb.DeleteResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the email %s cannot be found", req.Email),
+		}}, nil
+	case err == service.IDNotFound:
+		return &pb.DeleteResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the id %s cannot be found", req.Id),
+		}}, nil
+	case err == service.IDDoesNotMatchEmail, err == service.DeleteKeyEmpty:
+		return &pb.DeleteResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  err.Error(),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).WithField("id", req.Id).Error("Delete returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while deleting user, email=%s, id=%s", req.Email, req.Id)
+	}
+
+	server.Commit(txn)
+
+	return &pb.DeleteResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// ChangeEmail changes the email of a user while keeping its id.
+func (server *UserServer) ChangeEmail(ctx context.Context, req *pb.ChangeEmailReq) (*pb.ChangeEmailResp, error) {
+	logrus.WithField("email", req.Email).WithField("new_email", req.NewEmail).Info("change email request received")
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	user, err := server.Svc.ChangeEmail(txn, req.Email, req.NewEmail, req.Etag)
+	switch {
+	case err == service.EtagInvalid:
+		return &pb.ChangeEmailResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the etag '%s' is invalid", req.Etag),
+		}}, nil
+	case err == service.VersionMismatch:
+		return &pb.ChangeEmailResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_CONFLICT,
+			Msg:  fmt.Sprintf("the user %s was modified since the etag %s was read", req.Email, req.Etag),
+		}}, nil
+	case err == service.EmailNotFound:
+		return &pb.ChangeEmailResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the email %s cannot be found", req.Email),
+		}}, nil
+	case err == service.EmailAlreadyExists:
+		return &pb.ChangeEmailResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_FAILED, Msg: err.Error()}}, nil
+	case err == service.EmailEmpty:
+		return &pb.ChangeEmailResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  "the new email cannot be empty",
+		}}, nil
+	case err == service.EmailInvalid:
+		return &pb.ChangeEmailResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the new email '%s' is invalid", req.NewEmail),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).WithField("new_email", req.NewEmail).Error("ChangeEmail returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while changing the email, email=" + req.Email)
+	}
+
+	server.Commit(txn)
+
+	return &pb.ChangeEmailResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// Restore brings back a soft-deleted user.
+func (server *UserServer) Restore(ctx context.Context, req *pb.RestoreReq) (*pb.RestoreResp, error) {
+	logrus.WithField("email", req.Email).Info("restore request received")
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	user, err := server.Svc.Restore(txn, req.Email, req.Etag)
+	switch {
+	case err == service.EtagInvalid:
+		return &pb.RestoreResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the etag '%s' is invalid", req.Etag),
+		}}, nil
+	case err == service.VersionMismatch:
+		return &pb.RestoreResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_CONFLICT,
+			Msg:  fmt.Sprintf("the user %s was modified since the etag %s was read", req.Email, req.Etag),
+		}}, nil
+	case err == service.EmailNotFound:
+		return &pb.RestoreResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the email %s cannot be found", req.Email),
+		}}, nil
+	case err == service.UserNotDeleted:
+		return &pb.RestoreResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the user %s is not deleted", req.Email),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("Restore returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while restoring user, email=" + req.Email)
+	}
+
+	server.Commit(txn)
+
+	return &pb.RestoreResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
+// Purge removes a soft-deleted user for good.
+func (server *UserServer) Purge(ctx context.Context, req *pb.PurgeReq) (*pb.PurgeResp, error) {
+	logrus.WithField("email", req.Email).Info("purge request received")
+	txn := server.Txn(true)
+	defer server.Rollback(txn)
+
+	user, err := server.Svc.Purge(txn, req.Email, req.Etag)
+	switch {
+	case err == service.EtagInvalid:
+		return &pb.PurgeResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the etag '%s' is invalid", req.Etag),
+		}}, nil
+	case err == service.VersionMismatch:
+		return &pb.PurgeResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_CONFLICT,
+			Msg:  fmt.Sprintf("the user %s was modified since the etag %s was read", req.Email, req.Etag),
+		}}, nil
+	case err == service.EmailNotFound:
+		return &pb.PurgeResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the email %s cannot be found", req.Email),
+		}}, nil
+	case err == service.UserNotDeleted:
+		return &pb.PurgeResp{User: &pb.User{}, Status: &pb.Status{
+			Code: pb.Status_INVALID_QUERY,
+			Msg:  fmt.Sprintf("the user %s must be deleted before being purged", req.Email),
+		}}, nil
+	case err != nil:
+		logrus.WithError(err).WithField("email", req.Email).Error("Purge returned an unexpected error")
+		return nil, fmt.Errorf("something wrong happened while purging user, email=" + req.Email)
+	}
+
+	server.Commit(txn)
+
+	return &pb.PurgeResp{User: ToPB(user), Status: &pb.Status{Code: pb.Status_SUCCESS}}, nil
+}
+
 func FromPB(u *pb.User) service.User {
 	return service.User{
 		ID:        u.Id,
 		Age:       u.Age,
-		FirstName: u.Name.First,
-		LastName:  u.Name.Last,
+		FirstName: u.GetName().GetFirst(),
+		LastName:  u.GetName().GetLast(),
 		Email:     u.Email,
 		Phone:     u.Phone,
-		Address:   u.Address,
+		Address: service.Address{
+			Street:     u.GetAddress().GetStreet(),
+			City:       u.GetAddress().GetCity(),
+			Region:     u.GetAddress().GetRegion(),
+			PostalCode: u.GetAddress().GetPostalCode(),
+			Country:    u.GetAddress().GetCountry(),
+		},
+		Labels:    u.Labels,
+		ManagerID: u.ManagerId,
+	}
+}
+
+var sortFields = map[pb.OrderBy_Field]service.SortField{
+	pb.OrderBy_EMAIL:      service.SortEmail,
+	pb.OrderBy_FIRST_NAME: service.SortFirstName,
+	pb.OrderBy_LAST_NAME:  service.SortLastName,
+	pb.OrderBy_AGE:        service.SortAge,
+}
+
+// FromPBPage converts the paging and sorting fields of a request. Unknown
+// sort fields are turned into an invalid SortField so that the service
+// rejects them with OrderByInvalid.
+func FromPBPage(size int32, token string, orderBy []*pb.OrderBy) service.Page {
+	page := service.Page{Size: size, Token: token}
+	for _, o := range orderBy {
+		field, ok := sortFields[o.Field]
+		if !ok {
+			field = -1
+		}
+		page.OrderBy = append(page.OrderBy, service.SortKey{Field: field, Descending: o.Descending})
+	}
+	return page
+}
+
+// FromPBSearch converts the criteria of a search request. An unset age
+// bound stays nil so that the range is open on that side.
+func FromPBSearch(req *pb.SearchReq) service.SearchQuery {
+	query := service.SearchQuery{
+		Name:           req.Name,
+		EmailDomain:    req.EmailDomain,
+		Phone:          req.Phone,
+		Address:        req.Address,
+		City:           req.City,
+		Region:         req.Region,
+		Created:        FromPBTimeRange(req.CreatedAfter, req.CreatedBefore),
+		IncludeDeleted: req.IncludeDeleted,
+	}
+	if req.AgeFrom != nil {
+		from := req.AgeFrom.Value
+		query.AgeFrom = &from
 	}
+	if req.AgeToIncluded != nil {
+		to := req.AgeToIncluded.Value
+		query.AgeTo = &to
+	}
+	return query
 }
 
 func ToPB(u service.User) *pb.User {
 	return &pb.User{
-		Id:      u.ID,
-		Age:     u.Age,
-		Name:    &pb.Name{First: u.FirstName, Last: u.LastName},
-		Email:   u.Email,
-		Phone:   u.Phone,
-		Address: u.Address,
+		Id:        u.ID,
+		Age:       u.Age,
+		Name:      &pb.Name{First: u.FirstName, Last: u.LastName},
+		Email:     u.Email,
+		Phone:     u.Phone,
+		PhoneE164: u.PhoneE164,
+		Address: &pb.Address{
+			Street:     u.Address.Street,
+			City:       u.Address.City,
+			Region:     u.Address.Region,
+			PostalCode: u.Address.PostalCode,
+			Country:    u.Address.Country,
+		},
+		CreatedAt: toPBTime(u.CreatedAt),
+		UpdatedAt: toPBTime(u.UpdatedAt),
+		DeletedAt: toPBTime(u.DeletedAt),
+		Etag:      u.Etag(),
+		Labels:    u.Labels,
+		ManagerId: u.ManagerID,
+	}
+}
+
+// FromPBTimeRange turns the optional bounds of a time range into a
+// service.TimeRange; a missing bound leaves the range open on that side.
+func FromPBTimeRange(after, before *timestamppb.Timestamp) service.TimeRange {
+	var r service.TimeRange
+	if after != nil {
+		r.After = after.AsTime()
+	}
+	if before != nil {
+		r.Before = before.AsTime()
+	}
+	return r
+}
+
+// toPBTime returns nil for the zero time so that the unset timestamps are
+// omitted.
+func toPBTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
 	}
+	return timestamppb.New(t)
 }
 
 func ToPBs(users []service.User) []*pb.User {
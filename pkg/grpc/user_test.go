@@ -3,7 +3,9 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	memdb "github.com/hashicorp/go-memdb"
@@ -11,6 +13,10 @@ import (
 	service "github.com/maelvls/users-grpc/pkg/service"
 	pb "github.com/maelvls/users-grpc/schema/user"
 	td "github.com/maxatome/go-testdeep"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestUserServer_Create(t *testing.T) {
@@ -24,26 +30,26 @@ func TestUserServer_Create(t *testing.T) {
 		{
 			name: "when a user is created, it creates it",
 			givenReq: &pb.CreateReq{
-				User: &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+				User: &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{}},
 			},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
 				rec.
 					Create(someTxn(), service.User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}).
 					Return(nil)
 				rec.
-					GetByEmail(someTxn(), "zikuwcus@awobik.kr").
+					GetByEmail(someTxn(), "zikuwcus@awobik.kr", false).
 					Return(service.User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
 
 			},
 			want: &pb.CreateResp{
 				Status: &pb.Status{Code: pb.Status_SUCCESS},
-				User:   &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+				User:   &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{}},
 			},
 			wantErr: nil,
 		},
 		{
 			name:     "when the user already exists, return an understandable message",
-			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "zikuwcus@awobik.kr"}},
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "zikuwcus@awobik.kr", Address: &pb.Address{}}},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
 				rec.
 					Create(someTxn(), service.User{Email: "zikuwcus@awobik.kr"}).
@@ -52,9 +58,75 @@ func TestUserServer_Create(t *testing.T) {
 			want:    &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_FAILED, Msg: "email already exists"}},
 			wantErr: nil,
 		},
+		{
+			name:     "when the email is malformed, return an understandable message",
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "zikuwcus.awobik.kr", Address: &pb.Address{}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					Create(someTxn(), service.User{Email: "zikuwcus.awobik.kr"}).
+					Return(service.EmailInvalid)
+			},
+			want:    &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email 'zikuwcus.awobik.kr' is invalid"}},
+			wantErr: nil,
+		},
+		{
+			name:     "when the phone is invalid, return an understandable message",
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "zikuwcus@awobik.kr", Phone: "12", Address: &pb.Address{}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					Create(someTxn(), service.User{Email: "zikuwcus@awobik.kr", Phone: "12"}).
+					Return(service.PhoneInvalid)
+			},
+			want:    &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the phone '12' is invalid"}},
+			wantErr: nil,
+		},
+		{
+			name:     "when a label is invalid, return an understandable message",
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "zikuwcus@awobik.kr", Address: &pb.Address{}, Labels: map[string]string{"team": "a b"}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					Create(someTxn(), service.User{Email: "zikuwcus@awobik.kr", Labels: map[string]string{"team": "a b"}}).
+					Return(fmt.Errorf("%w: the value 'a b' of the label team is invalid", service.LabelInvalid))
+			},
+			want:    &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "invalid label: the value 'a b' of the label team is invalid"}},
+			wantErr: nil,
+		},
+		{
+			name:     "when the manager does not exist, return an understandable message",
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "zikuwcus@awobik.kr", Address: &pb.Address{}, ManagerId: "a1"}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					Create(someTxn(), service.User{Email: "zikuwcus@awobik.kr", ManagerID: "a1"}).
+					Return(service.ManagerNotFound)
+			},
+			want:    &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the manager a1 cannot be found"}},
+			wantErr: nil,
+		},
+		{
+			name:     "when the phone already exists, return an understandable message",
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "zikuwcus@awobik.kr", Phone: "906-568-2594", Address: &pb.Address{}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					Create(someTxn(), service.User{Email: "zikuwcus@awobik.kr", Phone: "906-568-2594"}).
+					Return(service.PhoneAlreadyExists)
+			},
+			want:    &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_FAILED, Msg: "phone already exists"}},
+			wantErr: nil,
+		},
+		{
+			name:     "when the id already exists, return an understandable message",
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					Create(someTxn(), service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}).
+					Return(service.IDAlreadyExists)
+			},
+			want:    &pb.CreateResp{User: &pb.User{}, Status: &pb.Status{Code: pb.Status_FAILED, Msg: "id already exists"}},
+			wantErr: nil,
+		},
 		{
 			name:     "unknown Create errors should error the grpc request and hide the actual err message",
-			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "foo@bar.io"}},
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "foo@bar.io", Address: &pb.Address{}}},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
 				rec.Create(someTxn(), service.User{Email: "foo@bar.io"}).Return(fmt.Errorf("some random error"))
 			},
@@ -63,10 +135,10 @@ func TestUserServer_Create(t *testing.T) {
 		},
 		{
 			name:     "unknown GetByEmail errors should error the grpc request and hide the actual err message",
-			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "foo@bar.io"}},
+			givenReq: &pb.CreateReq{User: &pb.User{Name: &pb.Name{}, Email: "foo@bar.io", Address: &pb.Address{}}},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
 				rec.Create(someTxn(), service.User{Email: "foo@bar.io"}).Return(nil)
-				rec.GetByEmail(someTxn(), "foo@bar.io").Return(service.User{}, fmt.Errorf("unknown error"))
+				rec.GetByEmail(someTxn(), "foo@bar.io", false).Return(service.User{}, fmt.Errorf("unknown error"))
 			},
 			want:    nil,
 			wantErr: fmt.Errorf("something wrong happened while finding the user, email=foo@bar.io"),
@@ -99,6 +171,148 @@ func TestUserServer_Create(t *testing.T) {
 	}
 }
 
+// fakeBulkStream sends the given requests to the server and records its
+// response. It implements pb.UserService_BulkCreateServer.
+type fakeBulkStream struct {
+	grpc.ServerStream
+	reqs []*pb.BulkCreateReq
+	resp *pb.BulkCreateResp
+}
+
+func (s *fakeBulkStream) Recv() (*pb.BulkCreateReq, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *fakeBulkStream) SendAndClose(resp *pb.BulkCreateResp) error {
+	s.resp = resp
+	return nil
+}
+
+func TestUserServer_BulkCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenReqs   []*pb.BulkCreateReq
+		givenMock   func(rec *mocks.MockUserServiceMockRecorder)
+		want        *pb.BulkCreateResp
+		wantCommits int
+		wantErr     error
+	}{
+		{
+			name: "creates the users and commits them by batches",
+			givenReqs: []*pb.BulkCreateReq{
+				{User: &pb.User{Email: "eza@pod.ru"}, BatchSize: 2},
+				{User: &pb.User{Email: "le@rec.gb"}},
+				{User: &pb.User{Email: "zikuwcus@awobik.kr"}},
+			},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Create(someTxn(), service.User{Email: "eza@pod.ru"}).Return(nil)
+				rec.Create(someTxn(), service.User{Email: "le@rec.gb"}).Return(nil)
+				rec.Create(someTxn(), service.User{Email: "zikuwcus@awobik.kr"}).Return(nil)
+			},
+			want:        &pb.BulkCreateResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Created: 3},
+			wantCommits: 2,
+		},
+		{
+			name: "reports the rejected users and still creates the other ones",
+			givenReqs: []*pb.BulkCreateReq{
+				{User: &pb.User{Email: "eza@pod.ru"}},
+				{User: &pb.User{Email: "eza@pod.ru"}},
+				{User: &pb.User{Email: "le@rec.gb", Phone: "12"}},
+				{},
+			},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Create(someTxn(), service.User{Email: "eza@pod.ru"}).Return(nil)
+				rec.Create(someTxn(), service.User{Email: "eza@pod.ru"}).Return(service.EmailAlreadyExists)
+				rec.Create(someTxn(), service.User{Email: "le@rec.gb", Phone: "12"}).Return(service.PhoneInvalid)
+			},
+			want: &pb.BulkCreateResp{
+				Status:  &pb.Status{Code: pb.Status_PARTIAL_SUCCESS, Msg: "1 users created, 3 rejected"},
+				Created: 1,
+				Failures: []*pb.BulkCreateFailure{
+					{Index: 1, Email: "eza@pod.ru", Status: &pb.Status{Code: pb.Status_FAILED, Msg: "email already exists"}},
+					{Index: 2, Email: "le@rec.gb", Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the phone '12' is invalid"}},
+					{Index: 3, Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the user object cannot be omitted"}},
+				},
+			},
+			wantCommits: 1,
+		},
+		{
+			name: "fails when all the users are rejected",
+			givenReqs: []*pb.BulkCreateReq{
+				{User: &pb.User{Email: "eza@"}},
+			},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Create(someTxn(), service.User{Email: "eza@"}).Return(service.EmailInvalid)
+			},
+			want: &pb.BulkCreateResp{
+				Status: &pb.Status{Code: pb.Status_FAILED, Msg: "none of the 1 users could be created"},
+				Failures: []*pb.BulkCreateFailure{
+					{Index: 0, Email: "eza@", Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email 'eza@' is invalid"}},
+				},
+			},
+			wantCommits: 1,
+		},
+		{
+			name:      "succeeds when no user is sent",
+			givenReqs: nil,
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.BulkCreateResp{Status: &pb.Status{Code: pb.Status_SUCCESS}},
+		},
+		{
+			name:      "should return an understandable message when the batch size is negative",
+			givenReqs: []*pb.BulkCreateReq{{User: &pb.User{Email: "eza@pod.ru"}, BatchSize: -1}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.BulkCreateResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the batch size cannot be negative"}},
+		},
+		{
+			name: "unknown errors should error the grpc request and hide the actual err message",
+			givenReqs: []*pb.BulkCreateReq{
+				{User: &pb.User{Email: "eza@pod.ru"}, BatchSize: 1},
+				{User: &pb.User{Email: "foo@bar.io"}},
+			},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Create(someTxn(), service.User{Email: "eza@pod.ru"}).Return(nil)
+				rec.Create(someTxn(), service.User{Email: "foo@bar.io"}).Return(fmt.Errorf("unknown error"))
+			},
+			wantErr:     fmt.Errorf("something wrong happened while creating users, email=foo@bar.io"),
+			wantCommits: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			commits := 0
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) { commits++ },
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			stream := &fakeBulkStream{reqs: tt.givenReqs}
+			gotErr := svc.BulkCreate(stream)
+
+			td.Cmp(t, commits, tt.wantCommits)
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, stream.resp, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserServer_List(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -112,26 +326,120 @@ func TestUserServer_List(t *testing.T) {
 			givenReq: &pb.ListReq{},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
 				rec.
-					List(someTxn()).
-					Return([]service.User{{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}}, nil)
+					List(someTxn(), false, service.TimeRange{}, service.Selector(nil), service.Page{}).
+					Return([]service.User{{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}}, "", nil)
 			},
 			want: &pb.SearchResp{
 				Status: &pb.Status{Code: pb.Status_SUCCESS},
-				Users:  []*pb.User{{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr"}},
+				Users:  []*pb.User{{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{}}},
 			},
 			wantErr: nil,
 		},
+		{
+			name:     "should return the next page token when there are more users",
+			givenReq: &pb.ListReq{PageSize: 1, PageToken: "eyJlbWFpbCI6ImV6YUBwb2QucnUifQ"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					List(someTxn(), false, service.TimeRange{}, service.Selector(nil), service.Page{Size: 1, Token: "eyJlbWFpbCI6ImV6YUBwb2QucnUifQ"}).
+					Return([]service.User{{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"}}, "eyJlbWFpbCI6ImxlQHJlYy5nYiJ9", nil)
+			},
+			want: &pb.SearchResp{
+				Status:        &pb.Status{Code: pb.Status_SUCCESS},
+				Users:         []*pb.User{{Name: &pb.Name{First: "Wayne", Last: "Keller"}, Age: 42, Id: "c7dca0a", Email: "le@rec.gb", Address: &pb.Address{}}},
+				NextPageToken: "eyJlbWFpbCI6ImxlQHJlYy5nYiJ9",
+			},
+		},
+		{
+			name:     "should pass the sort keys to the service",
+			givenReq: &pb.ListReq{OrderBy: []*pb.OrderBy{{Field: pb.OrderBy_LAST_NAME}, {Field: pb.OrderBy_AGE, Descending: true}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					List(someTxn(), false, service.TimeRange{}, service.Selector(nil), service.Page{OrderBy: []service.SortKey{{Field: service.SortLastName}, {Field: service.SortAge, Descending: true}}}).
+					Return(nil, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when a sort field is unknown",
+			givenReq: &pb.ListReq{OrderBy: []*pb.OrderBy{{Field: 42}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					List(someTxn(), false, service.TimeRange{}, service.Selector(nil), service.Page{OrderBy: []service.SortKey{{Field: -1}}}).
+					Return(nil, "", service.OrderByInvalid)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "invalid order by field"}, Users: []*pb.User{}},
+		},
+		{
+			name: "should pass the creation range to the service and return the timestamps",
+			givenReq: &pb.ListReq{
+				CreatedAfter:  timestamppb.New(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedBefore: timestamppb.New(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					List(someTxn(), false, service.TimeRange{After: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), Before: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)}, service.Selector(nil), service.Page{}).
+					Return([]service.User{{ID: "a4bcd38", Email: "zikuwcus@awobik.kr", CreatedAt: time.Date(2020, 6, 2, 8, 30, 0, 0, time.UTC), UpdatedAt: time.Date(2020, 6, 3, 8, 30, 0, 0, time.UTC)}}, "", nil)
+			},
+			want: &pb.SearchResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Users: []*pb.User{{Name: &pb.Name{}, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{},
+					CreatedAt: timestamppb.New(time.Date(2020, 6, 2, 8, 30, 0, 0, time.UTC)),
+					UpdatedAt: timestamppb.New(time.Date(2020, 6, 3, 8, 30, 0, 0, time.UTC)),
+				}},
+			},
+		},
+		{
+			name:     "should return an understandable message when the creation range is empty",
+			givenReq: &pb.ListReq{CreatedAfter: timestamppb.New(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)), CreatedBefore: timestamppb.New(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					List(someTxn(), false, service.TimeRange{After: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), Before: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)}, service.Selector(nil), service.Page{}).
+					Return(nil, "", service.TimeRangeInvalid)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the start of the time range must be before its end"}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the page token is invalid",
+			givenReq: &pb.ListReq{PageToken: "foo"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					List(someTxn(), false, service.TimeRange{}, service.Selector(nil), service.Page{Token: "foo"}).
+					Return(nil, "", service.InvalidPageToken)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "invalid page token"}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should pass the parsed selector to the service and return the labels",
+			givenReq: &pb.ListReq{Selector: "team=payments,!deprecated"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.
+					List(someTxn(), false, service.TimeRange{}, service.Selector{
+						{Key: "team", Op: service.Equals, Values: []string{"payments"}},
+						{Key: "deprecated", Op: service.DoesNotExist},
+					}, service.Page{}).
+					Return([]service.User{{ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Labels: map[string]string{"team": "payments"}}}, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{
+				{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}, Labels: map[string]string{"team": "payments"}},
+			}},
+		},
+		{
+			name:      "should return an understandable message when the selector is invalid",
+			givenReq:  &pb.ListReq{Selector: "team=pay ments"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "invalid label selector: 'team=pay ments' has an invalid value 'pay ments'"}, Users: []*pb.User{}},
+		},
 		{
 			name:     "unknown listing errors should error the grpc request and hide the actual err message",
 			givenReq: &pb.ListReq{},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
 				rec.
-					List(someTxn()).
-					Return(nil, fmt.Errorf("unknown list error"))
+					List(someTxn(), false, service.TimeRange{}, service.Selector(nil), service.Page{}).
+					Return(nil, "", fmt.Errorf("unknown list error"))
 			},
 			want: &pb.SearchResp{
 				Status: &pb.Status{Code: pb.Status_SUCCESS},
-				Users:  []*pb.User{{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr"}},
+				Users:  []*pb.User{{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{}}},
 			},
 			wantErr: fmt.Errorf("something wrong happened while listing users"),
 		},
@@ -175,9 +483,9 @@ func TestUserServer_SearchAge(t *testing.T) {
 			name:     "returns any found users",
 			givenReq: &pb.SearchAgeReq{AgeRange: &pb.SearchAgeReq_AgeRange{From: 35, ToIncluded: 38}},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.SearchAge(someTxn(), int32(35), int32(38)).Return([]service.User{{Age: 38, Email: "zikuwcus@awobik.kr"}}, nil)
+				rec.SearchAge(someTxn(), int32(35), int32(38), service.Page{}).Return([]service.User{{Age: 38, Email: "zikuwcus@awobik.kr"}}, "", nil)
 			},
-			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{{Name: &pb.Name{}, Age: 38, Email: "zikuwcus@awobik.kr"}}},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{{Name: &pb.Name{}, Age: 38, Email: "zikuwcus@awobik.kr", Address: &pb.Address{}}}},
 		},
 		{
 			name:      "should return an understandable message when age are wrong",
@@ -189,7 +497,7 @@ func TestUserServer_SearchAge(t *testing.T) {
 			name:     "should return an understandable message when AgeRange is omitted",
 			givenReq: &pb.SearchAgeReq{AgeRange: &pb.SearchAgeReq_AgeRange{From: 38, ToIncluded: 30}},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.SearchAge(someTxn(), int32(38), int32(30)).Return(nil, service.AgeFromIsGreaterThanAgeTo)
+				rec.SearchAge(someTxn(), int32(38), int32(30), service.Page{}).Return(nil, "", service.AgeFromIsGreaterThanAgeTo)
 			},
 			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "age is invalid, the 'from' age must be lower or equal to the 'to' age"}, Users: []*pb.User{}},
 		},
@@ -197,7 +505,7 @@ func TestUserServer_SearchAge(t *testing.T) {
 			name:     "unknown errors should error the grpc request and hide the actual err message",
 			givenReq: &pb.SearchAgeReq{AgeRange: &pb.SearchAgeReq_AgeRange{}},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.SearchAge(someTxn(), int32(0), int32(0)).Return(nil, fmt.Errorf("unknown error"))
+				rec.SearchAge(someTxn(), int32(0), int32(0), service.Page{}).Return(nil, "", fmt.Errorf("unknown error"))
 			},
 			want:    nil,
 			wantErr: fmt.Errorf("something wrong happened while searching users with their age"),
@@ -241,15 +549,18 @@ func TestNewUserServer(t *testing.T) {
 }
 
 func TestFromPB(t *testing.T) {
-	given := &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr"}
-	expect := service.User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}
+	given := &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608", Country: "USA"}}
+	expect := service.User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: service.Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608", Country: "USA"}}
 
 	td.Cmp(t, FromPB(given), expect)
+
+	// The address can be omitted.
+	td.Cmp(t, FromPB(&pb.User{Email: "zikuwcus@awobik.kr"}), service.User{Email: "zikuwcus@awobik.kr"})
 }
 
 func TestToPB(t *testing.T) {
-	given := service.User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}
-	expect := &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr"}
+	given := service.User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594", Address: service.Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}}
+	expect := &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 38, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594", Address: &pb.Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}}
 
 	td.Cmp(t, ToPB(given), expect)
 }
@@ -259,6 +570,7 @@ func someTxn() gomock.Matcher {
 }
 
 func TestUserServer_SearchName(t *testing.T) {
+	distance := func(d int32) *int32 { return &d }
 	tests := []struct {
 		name      string
 		givenReq  *pb.SearchNameReq
@@ -270,23 +582,69 @@ func TestUserServer_SearchName(t *testing.T) {
 			name:     "returns any found users",
 			givenReq: &pb.SearchNameReq{Query: "oba"},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.SearchName(someTxn(), "oba").Return([]service.User{{FirstName: "Foobar"}}, nil)
+				rec.SearchName(someTxn(), "oba", service.Page{}).Return([]service.User{{FirstName: "Foobar"}}, "", nil)
 			},
-			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{{Name: &pb.Name{First: "Foobar"}}}},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{{Name: &pb.Name{First: "Foobar"}, Address: &pb.Address{}}}},
 		},
 		{
 			name:     "should return an understandable message when quert is empty",
 			givenReq: &pb.SearchNameReq{Query: ""},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.SearchName(someTxn(), "").Return(nil, service.NameQueryEmpty)
+				rec.SearchName(someTxn(), "", service.Page{}).Return(nil, "", service.NameQueryEmpty)
 			},
 			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "name query cannot be empty"}, Users: []*pb.User{}},
 		},
+		{
+			name:     "returns the scores along with the users in fuzzy mode",
+			givenReq: &pb.SearchNameReq{Query: "brinna", MatchMode: pb.SearchNameReq_FUZZY, MaxDistance: wrapperspb.Int32(1)},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SearchNameFuzzy(someTxn(), "brinna", distance(1), service.Page{}).Return([]service.Match{{User: service.User{FirstName: "Brianna"}, Score: 0.75}}, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{{Name: &pb.Name{First: "Brianna"}, Address: &pb.Address{}}}, Scores: []float64{0.75}},
+		},
+		{
+			name:     "should use the fuzzy search when match_mode is FUZZY",
+			givenReq: &pb.SearchNameReq{Query: "brinna", MatchMode: pb.SearchNameReq_FUZZY},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SearchNameFuzzy(someTxn(), "brinna", (*int32)(nil), service.Page{}).Return(nil, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should pass a max distance of 0 as is",
+			givenReq: &pb.SearchNameReq{Query: "brinna", MatchMode: pb.SearchNameReq_FUZZY, MaxDistance: wrapperspb.Int32(0)},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SearchNameFuzzy(someTxn(), "brinna", distance(0), service.Page{}).Return(nil, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should use the phonetic search when match_mode is PHONETIC",
+			givenReq: &pb.SearchNameReq{Query: "chelton", MatchMode: pb.SearchNameReq_PHONETIC},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SearchNamePhonetic(someTxn(), "chelton", service.Page{}).Return([]service.User{{LastName: "Shelton"}}, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{{Name: &pb.Name{Last: "Shelton"}, Address: &pb.Address{}}}},
+		},
+		{
+			name:      "should return an understandable message when the match mode is unknown",
+			givenReq:  &pb.SearchNameReq{Query: "chelton", MatchMode: 42},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "unknown match mode 42"}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the max distance is negative",
+			givenReq: &pb.SearchNameReq{Query: "brinna", MatchMode: pb.SearchNameReq_FUZZY, MaxDistance: wrapperspb.Int32(-1)},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.SearchNameFuzzy(someTxn(), "brinna", distance(-1), service.Page{}).Return(nil, "", service.MaxDistanceNegative)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the maximum edit distance cannot be negative"}, Users: []*pb.User{}},
+		},
 		{
 			name:     "unknown errors should error the grpc request and hide the actual err message",
 			givenReq: &pb.SearchNameReq{Query: "blah"},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.SearchName(someTxn(), "blah").Return(nil, fmt.Errorf("unknown error"))
+				rec.SearchName(someTxn(), "blah", service.Page{}).Return(nil, "", fmt.Errorf("unknown error"))
 			},
 			want:    nil,
 			wantErr: fmt.Errorf("something wrong happened while finding users by name, query=blah"),
@@ -319,6 +677,118 @@ func TestUserServer_SearchName(t *testing.T) {
 	}
 }
 
+func TestUserServer_Search(t *testing.T) {
+	age := func(age int32) *int32 { return &age }
+
+	tests := []struct {
+		name      string
+		givenReq  *pb.SearchReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.SearchResp
+		wantErr   error
+	}{
+		{
+			name:     "returns any found users",
+			givenReq: &pb.SearchReq{Name: "flo", AgeFrom: wrapperspb.Int32(35), EmailDomain: "awobik.kr", PageSize: 1},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{Name: "flo", AgeFrom: age(35), EmailDomain: "awobik.kr"}, service.Page{Size: 1}).
+					Return([]service.User{{Age: 38, Email: "zikuwcus@awobik.kr"}}, "next", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{{Name: &pb.Name{}, Age: 38, Email: "zikuwcus@awobik.kr", Address: &pb.Address{}}}, NextPageToken: "next"},
+		},
+		{
+			name:     "should pass the parsed selector to the service",
+			givenReq: &pb.SearchReq{EmailDomain: "awobik.kr", Selector: "plan in (pro,team)"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{EmailDomain: "awobik.kr", Selector: service.Selector{
+					{Key: "plan", Op: service.In, Values: []string{"pro", "team"}},
+				}}, service.Page{}).Return(nil, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{}},
+		},
+		{
+			name:      "should return an understandable message when the selector is invalid",
+			givenReq:  &pb.SearchReq{Selector: "plan in pro"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "invalid label selector: 'plan in pro' has an invalid key"}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should pass the city and the region to the service",
+			givenReq: &pb.SearchReq{City: "Volta", Region: "Indiana"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{City: "Volta", Region: "Indiana"}, service.Page{}).Return(nil, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should keep the age range open when only age_to_included is given",
+			givenReq: &pb.SearchReq{AgeToIncluded: wrapperspb.Int32(0)},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{AgeTo: age(0)}, service.Page{}).Return(nil, "", nil)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when age are wrong",
+			givenReq: &pb.SearchReq{AgeFrom: wrapperspb.Int32(38), AgeToIncluded: wrapperspb.Int32(30)},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{AgeFrom: age(38), AgeTo: age(30)}, service.Page{}).Return(nil, "", service.AgeFromIsGreaterThanAgeTo)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "age is invalid, the 'from' age must be lower or equal to the 'to' age"}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the phone has no digits",
+			givenReq: &pb.SearchReq{Phone: "abc"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{Phone: "abc"}, service.Page{}).Return(nil, "", service.PhoneQueryInvalid)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the phone query must contain at least one digit"}, Users: []*pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the page token is invalid",
+			givenReq: &pb.SearchReq{Name: "flo", PageToken: "foo"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{Name: "flo"}, service.Page{Token: "foo"}).Return(nil, "", service.InvalidPageToken)
+			},
+			want: &pb.SearchResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "invalid page token"}, Users: []*pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.SearchReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Search(someTxn(), service.SearchQuery{}, service.Page{}).Return(nil, "", fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while searching users"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.Search(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserServer_GetByEmail(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -331,15 +801,23 @@ func TestUserServer_GetByEmail(t *testing.T) {
 			name:     "returns a user",
 			givenReq: &pb.GetByEmailReq{Email: "zikuwcus@awobik.kr"},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.GetByEmail(someTxn(), "zikuwcus@awobik.kr").Return(service.User{Email: "zikuwcus@awobik.kr"}, nil)
+				rec.GetByEmail(someTxn(), "zikuwcus@awobik.kr", false).Return(service.User{Email: "zikuwcus@awobik.kr"}, nil)
+			},
+			want: &pb.GetByEmailResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+		},
+		{
+			name:     "returns a soft-deleted user when include_deleted is set",
+			givenReq: &pb.GetByEmailReq{Email: "zikuwcus@awobik.kr", IncludeDeleted: true},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByEmail(someTxn(), "zikuwcus@awobik.kr", true).Return(service.User{Email: "zikuwcus@awobik.kr", DeletedAt: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)}, nil)
 			},
-			want: &pb.GetByEmailResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Email: "zikuwcus@awobik.kr", Name: &pb.Name{}}},
+			want: &pb.GetByEmailResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}, DeletedAt: timestamppb.New(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))}},
 		},
 		{
 			name:     "should return an understandable message when this email does not exist",
 			givenReq: &pb.GetByEmailReq{Email: "zikuwcus@awobik.kr"},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.GetByEmail(someTxn(), "zikuwcus@awobik.kr").Return(service.User{}, service.EmailNotFound)
+				rec.GetByEmail(someTxn(), "zikuwcus@awobik.kr", false).Return(service.User{}, service.EmailNotFound)
 			},
 			want: &pb.GetByEmailResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email zikuwcus@awobik.kr cannot be found"}, User: &pb.User{}},
 		},
@@ -347,7 +825,7 @@ func TestUserServer_GetByEmail(t *testing.T) {
 			name:     "unknown errors should error the grpc request and hide the actual err message",
 			givenReq: &pb.GetByEmailReq{Email: "foo@bar.io"},
 			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
-				rec.GetByEmail(someTxn(), "foo@bar.io").Return(service.User{}, fmt.Errorf("unknown error"))
+				rec.GetByEmail(someTxn(), "foo@bar.io", false).Return(service.User{}, fmt.Errorf("unknown error"))
 			},
 			want:    nil,
 			wantErr: fmt.Errorf("something wrong happened while getting a user by its email, email=foo@bar.io"),
@@ -379,3 +857,926 @@ func TestUserServer_GetByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUserServer_Update(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.UpdateReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.UpdateResp
+		wantErr   error
+	}{
+		{
+			name: "returns the updated user",
+			givenReq: &pb.UpdateReq{
+				Email:      "zikuwcus@awobik.kr",
+				User:       &pb.User{Age: 39},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"age"}},
+			},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Update(someTxn(), "zikuwcus@awobik.kr", []string{"age"}, service.User{Age: 39}, "").
+					Return(service.User{FirstName: "Flora", LastName: "Hale", Age: 39, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+			},
+			want: &pb.UpdateResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				User:   &pb.User{Name: &pb.Name{First: "Flora", Last: "Hale"}, Age: 39, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{}},
+			},
+		},
+		{
+			name: "passes the etag and returns the new etag",
+			givenReq: &pb.UpdateReq{
+				Email:      "zikuwcus@awobik.kr",
+				User:       &pb.User{Age: 39},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"age"}},
+				Etag:       "3-0",
+			},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Update(someTxn(), "zikuwcus@awobik.kr", []string{"age"}, service.User{Age: 39}, "3-0").
+					Return(service.User{Age: 39, ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Version: 4}, nil)
+			},
+			want: &pb.UpdateResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				User:   &pb.User{Name: &pb.Name{}, Age: 39, Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Address: &pb.Address{}, Etag: "4-0"},
+			},
+		},
+		{
+			name:     "should return a conflict when the user was modified since the etag was read",
+			givenReq: &pb.UpdateReq{Email: "zikuwcus@awobik.kr", User: &pb.User{}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"age"}}, Etag: "3-0"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Update(someTxn(), "zikuwcus@awobik.kr", []string{"age"}, service.User{}, "3-0").Return(service.User{}, service.VersionMismatch)
+			},
+			want: &pb.UpdateResp{Status: &pb.Status{Code: pb.Status_CONFLICT, Msg: "the user zikuwcus@awobik.kr was modified since the etag 3-0 was read"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the etag is invalid",
+			givenReq: &pb.UpdateReq{Email: "zikuwcus@awobik.kr", User: &pb.User{}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"age"}}, Etag: "foo"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Update(someTxn(), "zikuwcus@awobik.kr", []string{"age"}, service.User{}, "foo").Return(service.User{}, service.EtagInvalid)
+			},
+			want: &pb.UpdateResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the etag 'foo' is invalid"}, User: &pb.User{}},
+		},
+		{
+			name:      "should return an understandable message when the user is omitted",
+			givenReq:  &pb.UpdateReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.UpdateResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the user object cannot be omitted"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when this email does not exist",
+			givenReq: &pb.UpdateReq{Email: "zikuwcus@awobik.kr", User: &pb.User{}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"age"}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Update(someTxn(), "zikuwcus@awobik.kr", []string{"age"}, service.User{}, "").Return(service.User{}, service.EmailNotFound)
+			},
+			want: &pb.UpdateResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email zikuwcus@awobik.kr cannot be found"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the mask contains an unknown field",
+			givenReq: &pb.UpdateReq{Email: "zikuwcus@awobik.kr", User: &pb.User{}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"id"}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Update(someTxn(), "zikuwcus@awobik.kr", []string{"id"}, service.User{}, "").Return(service.User{}, fmt.Errorf("%w: id", service.UpdateFieldUnknown))
+			},
+			want: &pb.UpdateResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "unknown field in update mask: id"}, User: &pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.UpdateReq{Email: "foo@bar.io", User: &pb.User{}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"age"}}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Update(someTxn(), "foo@bar.io", []string{"age"}, service.User{}, "").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while updating user, email=foo@bar.io"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.Update(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.DeleteReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.DeleteResp
+		wantErr   error
+	}{
+		{
+			name:     "returns the deleted user",
+			givenReq: &pb.DeleteReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Delete(someTxn(), "zikuwcus@awobik.kr", "", "").Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+			},
+			want: &pb.DeleteResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+		},
+		{
+			name:     "should return an understandable message when this email does not exist",
+			givenReq: &pb.DeleteReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Delete(someTxn(), "zikuwcus@awobik.kr", "", "").Return(service.User{}, service.EmailNotFound)
+			},
+			want: &pb.DeleteResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email zikuwcus@awobik.kr cannot be found"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return a conflict when the user was modified since the etag was read",
+			givenReq: &pb.DeleteReq{Email: "zikuwcus@awobik.kr", Etag: "3-0"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Delete(someTxn(), "zikuwcus@awobik.kr", "", "3-0").Return(service.User{}, service.VersionMismatch)
+			},
+			want: &pb.DeleteResp{Status: &pb.Status{Code: pb.Status_CONFLICT, Msg: "the user was modified since the etag 3-0 was read"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when this id does not exist",
+			givenReq: &pb.DeleteReq{Id: "a4bcd38"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Delete(someTxn(), "", "a4bcd38", "").Return(service.User{}, service.IDNotFound)
+			},
+			want: &pb.DeleteResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the id a4bcd38 cannot be found"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when neither email nor id are given",
+			givenReq: &pb.DeleteReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Delete(someTxn(), "", "", "").Return(service.User{}, service.DeleteKeyEmpty)
+			},
+			want: &pb.DeleteResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "either the email or the id must be given"}, User: &pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.DeleteReq{Email: "foo@bar.io"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Delete(someTxn(), "foo@bar.io", "", "").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while deleting user, email=foo@bar.io, id="),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.Delete(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_ChangeEmail(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.ChangeEmailReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.ChangeEmailResp
+		wantErr   error
+	}{
+		{
+			name:     "returns the user with its new email",
+			givenReq: &pb.ChangeEmailReq{Email: "zikuwcus@awobik.kr", NewEmail: "flora@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.ChangeEmail(someTxn(), "zikuwcus@awobik.kr", "flora@awobik.kr", "").Return(service.User{ID: "a4bcd38", Email: "flora@awobik.kr"}, nil)
+			},
+			want: &pb.ChangeEmailResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Id: "a4bcd38", Email: "flora@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+		},
+		{
+			name:     "should return an understandable message when the new email is already used",
+			givenReq: &pb.ChangeEmailReq{Email: "zikuwcus@awobik.kr", NewEmail: "flora@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.ChangeEmail(someTxn(), "zikuwcus@awobik.kr", "flora@awobik.kr", "").Return(service.User{}, service.EmailAlreadyExists)
+			},
+			want: &pb.ChangeEmailResp{Status: &pb.Status{Code: pb.Status_FAILED, Msg: "email already exists"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the new email is malformed",
+			givenReq: &pb.ChangeEmailReq{Email: "zikuwcus@awobik.kr", NewEmail: "flora@"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.ChangeEmail(someTxn(), "zikuwcus@awobik.kr", "flora@", "").Return(service.User{}, service.EmailInvalid)
+			},
+			want: &pb.ChangeEmailResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the new email 'flora@' is invalid"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when this email does not exist",
+			givenReq: &pb.ChangeEmailReq{Email: "zikuwcus@awobik.kr", NewEmail: "flora@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.ChangeEmail(someTxn(), "zikuwcus@awobik.kr", "flora@awobik.kr", "").Return(service.User{}, service.EmailNotFound)
+			},
+			want: &pb.ChangeEmailResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email zikuwcus@awobik.kr cannot be found"}, User: &pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.ChangeEmailReq{Email: "foo@bar.io", NewEmail: "bar@bar.io"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.ChangeEmail(someTxn(), "foo@bar.io", "bar@bar.io", "").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while changing the email, email=foo@bar.io"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.ChangeEmail(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_BatchGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenMax  int
+		givenReq  *pb.BatchGetReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.BatchGetResp
+		wantErr   error
+	}{
+		{
+			name:     "returns the users found and the missing keys",
+			givenReq: &pb.BatchGetReq{Emails: []string{"zikuwcus@awobik.kr", "ra@mazvo.ca"}, Ids: []string{"b1d2e3f", "c0ffee1"}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByEmail(someTxn(), "zikuwcus@awobik.kr", false).Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+				rec.GetByEmail(someTxn(), "ra@mazvo.ca", false).Return(service.User{}, service.EmailNotFound)
+				rec.GetByID(someTxn(), "b1d2e3f").Return(service.User{ID: "b1d2e3f", Email: "hu@pod.ru"}, nil)
+				rec.GetByID(someTxn(), "c0ffee1").Return(service.User{}, service.IDNotFound)
+			},
+			want: &pb.BatchGetResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Users: []*pb.User{
+					{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}},
+					{Id: "b1d2e3f", Email: "hu@pod.ru", Name: &pb.Name{}, Address: &pb.Address{}},
+				},
+				MissingEmails: []string{"ra@mazvo.ca"},
+				MissingIds:    []string{"c0ffee1"},
+			},
+		},
+		{
+			name:     "returns a user found by both its email and its id only once",
+			givenReq: &pb.BatchGetReq{Emails: []string{"zikuwcus@awobik.kr"}, Ids: []string{"a4bcd38"}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByEmail(someTxn(), "zikuwcus@awobik.kr", false).Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+				rec.GetByID(someTxn(), "a4bcd38").Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+			},
+			want: &pb.BatchGetResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Users:  []*pb.User{{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+			},
+		},
+		{
+			name:      "should refuse more keys than the maximum",
+			givenMax:  2,
+			givenReq:  &pb.BatchGetReq{Emails: []string{"zikuwcus@awobik.kr", "ra@mazvo.ca"}, Ids: []string{"a4bcd38"}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      &pb.BatchGetResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "at most 2 emails and ids can be given at once, got 3"}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.BatchGetReq{Ids: []string{"a4bcd38"}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByID(someTxn(), "a4bcd38").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while getting users by their ids, id=a4bcd38"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			txns := 0
+			svc := &UserServer{
+				Txn:         func(b bool) *memdb.Txn { txns++; return nil },
+				Commit:      func(m *memdb.Txn) {},
+				Rollback:    func(m *memdb.Txn) {},
+				MaxBatchGet: tt.givenMax,
+				Svc:         mockUserSvc,
+			}
+
+			got, gotErr := svc.BatchGet(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+			td.CmpLte(t, txns, 1, "all the users must be read from the same transaction")
+		})
+	}
+}
+
+func TestUserServer_GetByID(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.GetByIDReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.GetByIDResp
+		wantErr   error
+	}{
+		{
+			name:     "returns a user",
+			givenReq: &pb.GetByIDReq{Id: "a4bcd38"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByID(someTxn(), "a4bcd38").Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+			},
+			want: &pb.GetByIDResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+		},
+		{
+			name:     "should return an understandable message when this id does not exist",
+			givenReq: &pb.GetByIDReq{Id: "a4bcd38"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByID(someTxn(), "a4bcd38").Return(service.User{}, service.IDNotFound)
+			},
+			want: &pb.GetByIDResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the id a4bcd38 cannot be found"}, User: &pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.GetByIDReq{Id: "a4bcd38"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByID(someTxn(), "a4bcd38").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while getting a user by its id, id=a4bcd38"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.GetByID(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_GetByPhone(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.GetByPhoneReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.GetByPhoneResp
+		wantErr   error
+	}{
+		{
+			name:     "returns a user",
+			givenReq: &pb.GetByPhoneReq{Phone: "906-568-2594"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByPhone(someTxn(), "906-568-2594").Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594"}, nil)
+			},
+			want: &pb.GetByPhoneResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594", Name: &pb.Name{}, Address: &pb.Address{}}},
+		},
+		{
+			name:     "should return an understandable message when this phone does not exist",
+			givenReq: &pb.GetByPhoneReq{Phone: "906-568-2594"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByPhone(someTxn(), "906-568-2594").Return(service.User{}, service.PhoneNotFound)
+			},
+			want: &pb.GetByPhoneResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the phone 906-568-2594 cannot be found"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when the phone is invalid",
+			givenReq: &pb.GetByPhoneReq{Phone: "12"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByPhone(someTxn(), "12").Return(service.User{}, service.PhoneInvalid)
+			},
+			want: &pb.GetByPhoneResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the phone '12' is invalid"}, User: &pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.GetByPhoneReq{Phone: "906-568-2594"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.GetByPhone(someTxn(), "906-568-2594").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while getting a user by its phone, phone=906-568-2594"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.GetByPhone(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_Restore(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.RestoreReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.RestoreResp
+		wantErr   error
+	}{
+		{
+			name:     "returns the restored user",
+			givenReq: &pb.RestoreReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Restore(someTxn(), "zikuwcus@awobik.kr", "").Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+			},
+			want: &pb.RestoreResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+		},
+		{
+			name:     "should return an understandable message when the user is not deleted",
+			givenReq: &pb.RestoreReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Restore(someTxn(), "zikuwcus@awobik.kr", "").Return(service.User{}, service.UserNotDeleted)
+			},
+			want: &pb.RestoreResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the user zikuwcus@awobik.kr is not deleted"}, User: &pb.User{}},
+		},
+		{
+			name:     "should return an understandable message when this email does not exist",
+			givenReq: &pb.RestoreReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Restore(someTxn(), "zikuwcus@awobik.kr", "").Return(service.User{}, service.EmailNotFound)
+			},
+			want: &pb.RestoreResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the email zikuwcus@awobik.kr cannot be found"}, User: &pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.RestoreReq{Email: "foo@bar.io"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Restore(someTxn(), "foo@bar.io", "").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while restoring user, email=foo@bar.io"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.Restore(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_Purge(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.PurgeReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.PurgeResp
+		wantErr   error
+	}{
+		{
+			name:     "returns the purged user",
+			givenReq: &pb.PurgeReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Purge(someTxn(), "zikuwcus@awobik.kr", "").Return(service.User{ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, nil)
+			},
+			want: &pb.PurgeResp{Status: &pb.Status{Code: pb.Status_SUCCESS}, User: &pb.User{Id: "a4bcd38", Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+		},
+		{
+			name:     "should return an understandable message when the user is not deleted",
+			givenReq: &pb.PurgeReq{Email: "zikuwcus@awobik.kr"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Purge(someTxn(), "zikuwcus@awobik.kr", "").Return(service.User{}, service.UserNotDeleted)
+			},
+			want: &pb.PurgeResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the user zikuwcus@awobik.kr must be deleted before being purged"}, User: &pb.User{}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.PurgeReq{Email: "foo@bar.io"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Purge(someTxn(), "foo@bar.io", "").Return(service.User{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while purging user, email=foo@bar.io"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.Purge(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeStream records what the server sends. It implements both
+// pb.UserService_StreamListServer and pb.UserService_StreamSearchServer.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pb.StreamResp
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func (s *fakeStream) Send(resp *pb.StreamResp) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func (s *fakeStream) SendMsg(m interface{}) error {
+	return s.Send(m.(*pb.StreamResp))
+}
+
+// streamUsers does what the service does with the fn it is given: it
+// calls fn with each user until fn fails.
+func streamUsers(fn func(service.User) error, users ...service.User) error {
+	for _, u := range users {
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestUserServer_StreamList(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	createdAt := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		givenCtx  context.Context
+		givenReq  *pb.StreamListReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      []*pb.StreamResp
+		wantErr   error
+	}{
+		{
+			name:     "sends each user",
+			givenCtx: context.Background(),
+			givenReq: &pb.StreamListReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamList(someTxn(), false, service.TimeRange{}, service.Selector(nil), gomock.Any()).DoAndReturn(func(txn *memdb.Txn, includeDeleted bool, created service.TimeRange, selector service.Selector, fn func(service.User) error) error {
+					return streamUsers(fn, service.User{Email: "zikuwcus@awobik.kr"}, service.User{Email: "foo@bar.io"})
+				})
+			},
+			want: []*pb.StreamResp{
+				{User: &pb.User{Email: "zikuwcus@awobik.kr", Name: &pb.Name{}, Address: &pb.Address{}}},
+				{User: &pb.User{Email: "foo@bar.io", Name: &pb.Name{}, Address: &pb.Address{}}},
+			},
+		},
+		{
+			name:     "stops sending when the client has gone away",
+			givenCtx: canceled,
+			givenReq: &pb.StreamListReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamList(someTxn(), false, service.TimeRange{}, service.Selector(nil), gomock.Any()).DoAndReturn(func(txn *memdb.Txn, includeDeleted bool, created service.TimeRange, selector service.Selector, fn func(service.User) error) error {
+					return streamUsers(fn, service.User{Email: "zikuwcus@awobik.kr"})
+				})
+			},
+			want:    nil,
+			wantErr: context.Canceled,
+		},
+		{
+			name:     "passes the filters to the service",
+			givenCtx: context.Background(),
+			givenReq: &pb.StreamListReq{IncludeDeleted: true, Selector: "team=payments", CreatedAfter: timestamppb.New(createdAt)},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamList(someTxn(), true, service.TimeRange{After: createdAt}, service.Selector{{Key: "team", Op: service.Equals, Values: []string{"payments"}}}, gomock.Any()).Return(nil)
+			},
+			want: nil,
+		},
+		{
+			name:      "when the selector is invalid, send an understandable message",
+			givenCtx:  context.Background(),
+			givenReq:  &pb.StreamListReq{Selector: "team=pay ments"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      []*pb.StreamResp{{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "invalid label selector: 'team=pay ments' has an invalid value 'pay ments'"}}},
+		},
+		{
+			name:     "when the creation range is empty, send an understandable message",
+			givenCtx: context.Background(),
+			givenReq: &pb.StreamListReq{CreatedAfter: timestamppb.New(createdAt), CreatedBefore: timestamppb.New(createdAt)},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamList(someTxn(), false, service.TimeRange{After: createdAt, Before: createdAt}, service.Selector(nil), gomock.Any()).Return(service.TimeRangeInvalid)
+			},
+			want: []*pb.StreamResp{{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: service.TimeRangeInvalid.Error()}}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenCtx: context.Background(),
+			givenReq: &pb.StreamListReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamList(someTxn(), false, service.TimeRange{}, service.Selector(nil), gomock.Any()).Return(fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while listing users"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			stream := &fakeStream{ctx: tt.givenCtx}
+			gotErr := svc.StreamList(tt.givenReq, stream)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, stream.sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_StreamSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.StreamSearchReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      []*pb.StreamResp
+		wantErr   error
+	}{
+		{
+			name:     "sends the users matching the name",
+			givenReq: &pb.StreamSearchReq{Name: "oba"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamSearchName(someTxn(), "oba", gomock.Any()).DoAndReturn(func(txn *memdb.Txn, query string, fn func(service.User) error) error {
+					return streamUsers(fn, service.User{FirstName: "Foobar"})
+				})
+			},
+			want: []*pb.StreamResp{{User: &pb.User{Name: &pb.Name{First: "Foobar"}, Address: &pb.Address{}}}},
+		},
+		{
+			name:     "sends the users in the age range",
+			givenReq: &pb.StreamSearchReq{AgeRange: &pb.SearchAgeReq_AgeRange{From: 35, ToIncluded: 38}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamSearchAge(someTxn(), int32(35), int32(38), gomock.Any()).DoAndReturn(func(txn *memdb.Txn, from, to int32, fn func(service.User) error) error {
+					return streamUsers(fn, service.User{Age: 38})
+				})
+			},
+			want: []*pb.StreamResp{{User: &pb.User{Age: 38, Name: &pb.Name{}, Address: &pb.Address{}}}},
+		},
+		{
+			name:      "should send an understandable message when both the name and the age range are given",
+			givenReq:  &pb.StreamSearchReq{Name: "oba", AgeRange: &pb.SearchAgeReq_AgeRange{}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      []*pb.StreamResp{{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "cannot search by age and by name at the same time"}}},
+		},
+		{
+			name:      "should send an understandable message when neither the name nor the age range are given",
+			givenReq:  &pb.StreamSearchReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {},
+			want:      []*pb.StreamResp{{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "one of name or ageRange must be given"}}},
+		},
+		{
+			name:     "should send an understandable message when the ages are wrong",
+			givenReq: &pb.StreamSearchReq{AgeRange: &pb.SearchAgeReq_AgeRange{From: 38, ToIncluded: 30}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamSearchAge(someTxn(), int32(38), int32(30), gomock.Any()).Return(service.AgeFromIsGreaterThanAgeTo)
+			},
+			want: []*pb.StreamResp{{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "age is invalid, the 'from' age must be lower or equal to the 'to' age"}}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.StreamSearchReq{Name: "blah"},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.StreamSearchName(someTxn(), "blah", gomock.Any()).Return(fmt.Errorf("unknown error"))
+			},
+			wantErr: fmt.Errorf("something wrong happened while searching users"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			stream := &fakeStream{ctx: context.Background()}
+			gotErr := svc.StreamSearch(tt.givenReq, stream)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, stream.sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServer_Stats(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenReq  *pb.StatsReq
+		givenMock func(rec *mocks.MockUserServiceMockRecorder)
+		want      *pb.StatsResp
+		wantErr   error
+	}{
+		{
+			name:     "returns the statistics",
+			givenReq: &pb.StatsReq{AgeBuckets: []int32{30}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Stats(someTxn(), []int32{30}).Return(service.Stats{
+					Total: 3, MinAge: 20, MaxAge: 40, MeanAge: 30, MedianAge: 30,
+					AgeBuckets:   []service.AgeBucket{{From: 0, To: 30, Count: 1}, {From: 30, Count: 2}},
+					EmailDomains: []service.Count{{Key: "pod.ru", Count: 3}},
+					Regions:      []service.Count{{Key: "Indiana", Count: 2}},
+				}, nil)
+			},
+			want: &pb.StatsResp{
+				Status: &pb.Status{Code: pb.Status_SUCCESS},
+				Total:  3, MinAge: 20, MaxAge: 40, MeanAge: 30, MedianAge: 30,
+				AgeBuckets:   []*pb.AgeBucket{{From: 0, To: 30, Count: 1}, {From: 30, Count: 2}},
+				EmailDomains: []*pb.Count{{Key: "pod.ru", Count: 3}},
+				Regions:      []*pb.Count{{Key: "Indiana", Count: 2}},
+			},
+		},
+		{
+			name:     "uses the default age buckets when none are given",
+			givenReq: &pb.StatsReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Stats(someTxn(), service.DefaultAgeBuckets).Return(service.Stats{}, nil)
+			},
+			want: &pb.StatsResp{Status: &pb.Status{Code: pb.Status_SUCCESS}},
+		},
+		{
+			name:     "should return an understandable message when the buckets are invalid",
+			givenReq: &pb.StatsReq{AgeBuckets: []int32{30, 18}},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Stats(someTxn(), []int32{30, 18}).Return(service.Stats{}, service.AgeBucketsInvalid)
+			},
+			want: &pb.StatsResp{Status: &pb.Status{Code: pb.Status_INVALID_QUERY, Msg: "the age bucket bounds [30 18] must be positive and increasing"}},
+		},
+		{
+			name:     "unknown errors should error the grpc request and hide the actual err message",
+			givenReq: &pb.StatsReq{},
+			givenMock: func(rec *mocks.MockUserServiceMockRecorder) {
+				rec.Stats(someTxn(), service.DefaultAgeBuckets).Return(service.Stats{}, fmt.Errorf("unknown error"))
+			},
+			want:    nil,
+			wantErr: fmt.Errorf("something wrong happened while computing the statistics"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockUserSvc := mocks.NewMockUserService(ctl)
+			tt.givenMock(mockUserSvc.EXPECT())
+
+			svc := &UserServer{
+				Txn:      func(b bool) *memdb.Txn { return nil },
+				Commit:   func(m *memdb.Txn) {},
+				Rollback: func(m *memdb.Txn) {},
+				Svc:      mockUserSvc,
+			}
+
+			got, gotErr := svc.Stats(context.Background(), tt.givenReq)
+
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/maelvls/users-grpc/pkg/userfields"
+)
+
+// Address is a postal address. It lives in userfields so that the CLI can
+// parse addresses without depending on this package.
+type Address = userfields.Address
+
+// addressFieldIndex is a memdb indexer that indexes a user under one of
+// the fields of its address, folded so that "indiana" finds "Indiana".
+// Users whose field is empty are not indexed.
+type addressFieldIndex struct {
+	name  string
+	field func(Address) string
+}
+
+func (idx addressFieldIndex) FromObject(raw interface{}) (bool, []byte, error) {
+	u, ok := raw.(*User)
+	if !ok {
+		return false, nil, fmt.Errorf("%s index: expected a *User, got %T", idx.name, raw)
+	}
+
+	val := fold(idx.field(u.Address))
+	if val == "" {
+		return false, nil, nil
+	}
+
+	return true, []byte(val + "\x00"), nil
+}
+
+func (idx addressFieldIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%s index: must provide only a single argument", idx.name)
+	}
+	val, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s index: argument must be a string: %#v", idx.name, args[0])
+	}
+
+	return []byte(fold(val) + "\x00"), nil
+}
+
+var (
+	cityIndex   = addressFieldIndex{name: "city", field: func(a Address) string { return a.City }}
+	regionIndex = addressFieldIndex{name: "region", field: func(a Address) string { return a.Region }}
+)
+
+// walkAddress goes through the users whose address field is equal to the
+// given value once folded, sorted by email and starting at the given
+// email. The index must be cityIndex or regionIndex.
+func walkAddress(txn *memdb.Txn, idx addressFieldIndex, value, fromEmail string, fn func(*User) bool) error {
+	it, err := txn.LowerBound("user", idx.name, value, fromEmail)
+	if err != nil {
+		return err
+	}
+
+	value = fold(value)
+	for raw := it.Next(); raw != nil; raw = it.Next() {
+		u := raw.(*User)
+		if fold(idx.field(u.Address)) != value {
+			break
+		}
+		if !fn(u) {
+			break
+		}
+	}
+
+	return nil
+}
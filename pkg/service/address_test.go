@@ -0,0 +1,18 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestLoadSampleUsers_addresses(t *testing.T) {
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	defer txn.Abort()
+	td.CmpNoError(t, UserSvc{}.LoadSampleUsers(txn))
+
+	users, _, err := UserSvc{}.Search(txn, SearchQuery{Region: "north carolina"}, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, users, td.All(td.NotEmpty(), td.ArrayEach(td.Smuggle("Address.Region", "North Carolina"))))
+}
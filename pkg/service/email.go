@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+var EmailInvalid = errors.New("invalid email")
+
+// validateEmail makes sure that the email is a bare address such as
+// "brianna.shelton@email.org"; display names such as in "Brianna
+// <brianna.shelton@email.org>" and surrounding spaces are rejected.
+//
+// Possible errors: EmailEmpty, EmailInvalid.
+func validateEmail(email string) error {
+	if email == "" {
+		return EmailEmpty
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return EmailInvalid
+	}
+
+	// Although "brianna@localhost" is a valid address, we only want
+	// addresses that can be reached from anywhere.
+	at := strings.LastIndex(email, "@")
+	if !strings.Contains(email[at+1:], ".") {
+		return EmailInvalid
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func Test_validateEmail(t *testing.T) {
+	tests := []struct {
+		given   string
+		wantErr error
+	}{
+		{given: "brianna.shelton@email.org"},
+		{given: "Brianna.Shelton@Email.org"},
+		{given: "brianna+users@mail.email.org"},
+		{given: "", wantErr: EmailEmpty},
+		{given: "brianna.shelton", wantErr: EmailInvalid},
+		{given: "brianna.shelton@", wantErr: EmailInvalid},
+		{given: "@email.org", wantErr: EmailInvalid},
+		{given: "brianna@localhost", wantErr: EmailInvalid},
+		{given: "brianna shelton@email.org", wantErr: EmailInvalid},
+		{given: " brianna.shelton@email.org", wantErr: EmailInvalid},
+		{given: "Brianna <brianna.shelton@email.org>", wantErr: EmailInvalid},
+		{given: "brianna@shelton@email.org", wantErr: EmailInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			td.Cmp(t, validateEmail(tt.given), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var InvalidFoldRule = errors.New("invalid fold rule")
+
+// fold turns a name or an address into lowercase ASCII so that searching
+// "soren" finds "Søren" and "aleksandr" finds "Александр". It is used both
+// when searching and when indexing the users, which means that the same
+// folding must be used for the whole lifetime of the database.
+//
+// The folding goes like this:
+//
+//  1. the compatibility characters are replaced (NFKC), e.g. the
+//     ligature "ﬁ" becomes "fi",
+//  2. the string is lowercased,
+//  3. the extra rules given to SetFoldRules are applied,
+//  4. the letters of the transliteration table (special Latin letters,
+//     Cyrillic and Greek) are replaced,
+//  5. the diacritics of the remaining letters are removed, e.g. "é"
+//     becomes "e".
+var fold = newFolder(nil)
+
+// SetFoldRules adds extra rules to the folding done before searching or
+// indexing names and addresses. For example, the rule "ü" → "ue" lets
+// "mueller" find "Müller" (but "muller" won't find it anymore). The rules
+// are applied before the built-in transliteration and their keys are
+// lowercased.
+//
+// Since the indexes depend on the folding, SetFoldRules must be called
+// before the database is created.
+func SetFoldRules(rules map[string]string) {
+	fold = newFolder(rules)
+}
+
+// ParseFoldRules parses rules of the form "ü=ue,ö=oe".
+//
+// Possible errors: InvalidFoldRule.
+func ParseFoldRules(s string) (map[string]string, error) {
+	rules := make(map[string]string)
+	if s == "" {
+		return rules, nil
+	}
+
+	for _, rule := range strings.Split(s, ",") {
+		parts := strings.SplitN(rule, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%w: '%s', expected the form 'from=to'", InvalidFoldRule, rule)
+		}
+		rules[parts[0]] = parts[1]
+	}
+
+	return rules, nil
+}
+
+func newFolder(rules map[string]string) func(string) string {
+	// The longest keys come first so that "sch" is tried before "s".
+	keys := make([]string, 0, len(rules))
+	for from := range rules {
+		keys = append(keys, from)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	var oldnew []string
+	for _, from := range keys {
+		oldnew = append(oldnew, norm.NFKC.String(strings.ToLower(from)), rules[from])
+	}
+	extra := strings.NewReplacer(oldnew...)
+
+	return func(s string) string {
+		s = extra.Replace(strings.ToLower(norm.NFKC.String(s)))
+
+		var b strings.Builder
+		b.Grow(len(s))
+		var prev rune // The previous letter without its diacritics.
+		for _, r := range s {
+			if r < unicode.MaxASCII {
+				b.WriteRune(r)
+				prev = r
+				continue
+			}
+
+			// Some letters such as "й" must be transliterated before
+			// their diacritics are removed, which is why they are not
+			// decomposed.
+			letters := []rune{r}
+			if _, ok := transliterations[r]; !ok {
+				letters = []rune(norm.NFD.String(string(r)))
+			}
+
+			for _, l := range letters {
+				t, ok := transliterations[l]
+				switch {
+				case unicode.Is(unicode.Mn, l):
+					continue // Diacritics are removed.
+				case prev == 'ο' && l == 'υ':
+					b.WriteString("u") // The Greek "ου" is "ou".
+				case ok:
+					b.WriteString(t)
+				default:
+					b.WriteRune(l)
+				}
+				prev = l
+			}
+		}
+		return b.String()
+	}
+}
+
+// The lowercase letters that cannot be turned into ASCII by only removing
+// their diacritics.
+var transliterations = map[rune]string{
+	// Latin letters and ligatures that are not decomposed by NFKC.
+	'æ': "ae", 'œ': "oe", 'ß': "ss", 'ø': "o", 'ł': "l", 'đ': "d",
+	'ð': "d", 'þ': "th", 'ı': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "ng",
+	'ĸ': "k", 'ſ': "s",
+
+	// Cyrillic (Russian, Ukrainian, Belarusian and Serbian), using the
+	// transliteration of passports.
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+	'ђ': "dj", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz",
+
+	// Greek (ELOT 743). The accents are removed beforehand since they are
+	// combining marks.
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
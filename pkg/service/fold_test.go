@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func Test_fold(t *testing.T) {
+	tests := []struct {
+		name  string
+		given string
+		want  string
+	}{
+		{name: "lowercases", given: "Brianna SHELTON", want: "brianna shelton"},
+		{name: "removes diacritics", given: "Maël Hélène Ñúñez", want: "mael helene nunez"},
+		{name: "removes decomposed diacritics", given: "Mae\u0308l", want: "mael"},
+		{name: "folds Danish and Norwegian letters", given: "Søren Kierkegård", want: "soren kierkegard"},
+		{name: "folds German sharp s", given: "Strauß", want: "strauss"},
+		{name: "folds Latin ligatures", given: "Ærø Œuvre", want: "aero oeuvre"},
+		{name: "folds Polish l", given: "Łukasz Wałęsa", want: "lukasz walesa"},
+		{name: "folds Icelandic letters", given: "Þór Guðmundsson", want: "thor gudmundsson"},
+		{name: "folds Croatian d", given: "Đurđević", want: "durdevic"},
+		{name: "folds Turkish dotless i", given: "Işıl", want: "isil"},
+		{name: "expands compatibility ligatures", given: "Ǆemal ﬁnn", want: "dzemal finn"},
+		{name: "transliterates Russian", given: "Александр Достоевский", want: "aleksandr dostoevskiy"},
+		{name: "transliterates Ukrainian", given: "Її Євген Ґонта", want: "yiyi yevgen gonta"},
+		{name: "transliterates Russian hard and soft signs", given: "Объедков Игорь", want: "obedkov igor"},
+		{name: "transliterates Greek", given: "Γιώργος Παπαδόπουλος", want: "giorgos papadopoulos"},
+		{name: "transliterates Greek final sigma and digraphs", given: "Θεοφάνης Χατζής Ψυχάρης", want: "theofanis chatzis psycharis"},
+		{name: "keeps ASCII punctuation and digits", given: "O'Neil-Smith 2nd", want: "o'neil-smith 2nd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td.Cmp(t, fold(tt.given), tt.want)
+		})
+	}
+}
+
+func Test_newFolder(t *testing.T) {
+	fold := newFolder(map[string]string{"Ü": "ue", "ö": "oe", "sch": "sh"})
+
+	td.Cmp(t, fold("Müller"), "mueller")
+	td.Cmp(t, fold("MÜLLER"), "mueller")
+	td.Cmp(t, fold("Mu\u0308ller"), "mueller") // Decomposed "ü".
+	td.Cmp(t, fold("Schröder"), "shroeder")
+	td.Cmp(t, fold("Müñoz"), "muenoz") // The other letters are still folded.
+}
+
+func TestParseFoldRules(t *testing.T) {
+	rules, err := ParseFoldRules("ü=ue,ö=oe,ъ=")
+	td.CmpNoError(t, err)
+	td.Cmp(t, rules, map[string]string{"ü": "ue", "ö": "oe", "ъ": ""})
+
+	rules, err = ParseFoldRules("")
+	td.CmpNoError(t, err)
+	td.Cmp(t, rules, map[string]string{})
+
+	_, err = ParseFoldRules("ü")
+	td.CmpTrue(t, errors.Is(err, InvalidFoldRule))
+
+	_, err = ParseFoldRules("=ue")
+	td.CmpTrue(t, errors.Is(err, InvalidFoldRule))
+}
+
+func TestSearchName_folding(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Søren", LastName: "Kierkegård", ID: "a1", Email: "soren@email.dk"},
+		{FirstName: "Александр", LastName: "Пушкин", ID: "a2", Email: "pushkin@email.ru"},
+		{FirstName: "Γιώργος", LastName: "Σεφέρης", ID: "a3", Email: "seferis@email.gr"},
+		{FirstName: "Łukasz", LastName: "Strauß", ID: "a4", Email: "lukasz@email.pl"},
+	}
+
+	tests := []struct {
+		query string
+		want  []User
+	}{
+		{query: "soren", want: []User{users[0]}},
+		{query: "kierkegard", want: []User{users[0]}},
+		{query: "aleksandr", want: []User{users[1]}},
+		{query: "pushkin", want: []User{users[1]}},
+		{query: "seferis", want: []User{users[2]}},
+		{query: "Σεφέρης", want: []User{users[2]}},
+		{query: "strauss", want: []User{users[3]}},
+		{query: "lukasz", want: []User{users[3]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+			fillDBWith(users)(txn)
+
+			got, _, err := UserSvc{}.SearchName(txn, tt.query, Page{})
+			td.CmpNoError(t, err)
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+var MaxDistanceNegative = errors.New("the maximum edit distance cannot be negative")
+
+// DefaultMaxDistance is the maximum edit distance used by SearchNameFuzzy
+// when none is given.
+const DefaultMaxDistance = 2
+
+// Match is a user found by a fuzzy search along with how close it is to
+// the query. The score goes from 0 (excluded) to 1, which means that the
+// query was found as is.
+type Match struct {
+	User  User
+	Score float64
+}
+
+// SearchNameFuzzy is the typo-tolerant version of SearchName: the users
+// whose first or last name contain a substring that is at most
+// maxDistance edits (insertions, deletions or substitutions of a single
+// character) away from the query are returned. For example, "brinna"
+// finds "Brianna" with a distance of 1. When maxDistance is nil,
+// DefaultMaxDistance is used. The distance is always kept below the
+// length of the query.
+//
+// The matches are ranked by score, the best match first, unless
+// page.OrderBy is given. See List for how page works.
+//
+// Possible errors: NameQueryEmpty, MaxDistanceNegative, PageSizeNegative,
+// InvalidPageToken, OrderByInvalid.
+func (UserSvc) SearchNameFuzzy(txn *memdb.Txn, query string, givenMaxDistance *int32, page Page) ([]Match, string, error) {
+	if query == "" {
+		return nil, "", NameQueryEmpty
+	}
+	maxDistance := int32(DefaultMaxDistance)
+	if givenMaxDistance != nil {
+		maxDistance = *givenMaxDistance
+	}
+	if maxDistance < 0 {
+		return nil, "", MaxDistanceNegative
+	}
+	if page.Size < 0 {
+		return nil, "", PageSizeNegative
+	}
+	err := validateOrderBy(page.OrderBy)
+	if err != nil {
+		return nil, "", err
+	}
+	c, err := decodeCursor(page.Token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := []rune(fold(query))
+
+	// Otherwise, a short query like "ab" would match every user since
+	// removing both characters is only two edits away from anything.
+	if int(maxDistance) >= len(q) {
+		maxDistance = int32(len(q) - 1)
+	}
+
+	var matches []Match
+	err = walkFuzzy(txn, string(q), maxDistance, filter(notDeleted, func(u *User) bool {
+		d := substringDistance(q, []rune(fold(u.FirstName)))
+		if last := substringDistance(q, []rune(fold(u.LastName))); last < d {
+			d = last
+		}
+		if d <= int(maxDistance) {
+			matches = append(matches, Match{User: *u, Score: score(d, len(q))})
+		}
+		return true
+	}))
+	if err != nil {
+		return nil, "", fmt.Errorf("fuzzy searching users: %w", err)
+	}
+
+	cmp := matchComparator(page.OrderBy)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return cmp(matches[i], matches[j]) < 0
+	})
+
+	// Like for sorted pages, the page starts right after the cursor.
+	i := 0
+	if page.Token != "" {
+		last := Match{User: *c.user(), Score: c.Score}
+		i = sort.Search(len(matches), func(i int) bool {
+			return cmp(matches[i], last) > 0
+		})
+	}
+	matches = matches[i:]
+
+	if page.Size == 0 || len(matches) <= int(page.Size) {
+		return matches, "", nil
+	}
+
+	matches = matches[:page.Size]
+	last := matches[len(matches)-1]
+	next := newCursor(last.User, true)
+	next.Score = last.Score
+
+	return matches, encodeCursor(next), nil
+}
+
+// matchComparator returns a function that compares two matches using the
+// given sort keys or, when there are none, using their scores; the email
+// is used as a last resort. See newComparator.
+func matchComparator(keys []SortKey) func(a, b Match) int {
+	cmpUsers := newComparator(keys)
+
+	return func(a, b Match) int {
+		if len(keys) == 0 {
+			// The best scores come first.
+			if c := -compareFloats(a.Score, b.Score); c != 0 {
+				return c
+			}
+		}
+		return cmpUsers(&a.User, &b.User)
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// score turns an edit distance into a score between 0 and 1. The distance
+// must be lower than the length of the query.
+func score(distance, queryLen int) float64 {
+	return 1 - float64(distance)/float64(queryLen)
+}
+
+// walkFuzzy calls fn for the users that may be at most maxDistance edits
+// away from the folded query. It relies on the fact that each edit
+// changes at most three trigrams: a user can only match when its names
+// have at least as many of the query's trigrams as the query has minus
+// three per edit. When that lower bound is zero, all the users are
+// walked.
+func walkFuzzy(txn *memdb.Txn, foldedQuery string, maxDistance int32, fn func(*User) bool) error {
+	set := make(map[string]struct{})
+	for _, tg := range trigrams(foldedQuery) {
+		set[tg] = struct{}{}
+	}
+
+	minShared := len(set) - 3*int(maxDistance)
+	if minShared <= 0 {
+		return walkEmail(txn, "", fn)
+	}
+
+	shared := make(map[string]int)
+	users := make(map[string]*User)
+	for tg := range set {
+		it, err := txn.Get("user", "trigram", tg)
+		if err != nil {
+			return err
+		}
+		for raw := it.Next(); raw != nil; raw = it.Next() {
+			u := raw.(*User)
+			shared[u.ID]++
+			users[u.ID] = u
+		}
+	}
+
+	for id, n := range shared {
+		if n < minShared {
+			continue
+		}
+		if !fn(users[id]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// substringDistance returns the smallest edit distance between the query
+// and any substring of the text (Sellers' algorithm). It is 0 when the
+// text contains the query.
+func substringDistance(query, text []rune) int {
+	// prev[j] is the distance between the first i-1 runes of the query
+	// and the best substring of the text ending at j. Since the substring
+	// can start anywhere, the first row is all zeroes.
+	prev := make([]int, len(text)+1)
+	cur := make([]int, len(text)+1)
+
+	for i := 1; i <= len(query); i++ {
+		cur[0] = i
+		for j := 1; j <= len(text); j++ {
+			cost := 1
+			if query[i-1] == text[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost // Substitution.
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1 // Deletion.
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1 // Insertion.
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	best := len(query)
+	for _, d := range prev {
+		if d < best {
+			best = d
+		}
+	}
+	return best
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func Test_substringDistance(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        int
+	}{
+		{query: "brianna", text: "brianna", want: 0},
+		{query: "ann", text: "brianna", want: 0},
+		{query: "brinna", text: "brianna", want: 1},
+		{query: "braina", text: "brianna", want: 2},
+		{query: "shleton", text: "shelton", want: 2},
+		{query: "xyz", text: "brianna", want: 3},
+		{query: "abc", text: "", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+" in "+tt.text, func(t *testing.T) {
+			td.Cmp(t, substringDistance([]rune(tt.query), []rune(tt.text)), tt.want)
+		})
+	}
+}
+
+func TestSearchNameFuzzy(t *testing.T) {
+	distance := func(d int32) *int32 { return &d }
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Brianna", LastName: "Shelton", Age: 52, ID: "5cfdf218", Email: "brianna.shelton@email.org"},
+		{FirstName: "Brina", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+		{FirstName: "Bríanna", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+	}
+
+	t.Run("should rank the users by score", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, next, err := UserSvc{}.SearchNameFuzzy(txn, "Brinna", nil, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []Match{
+			{User: users[0], Score: 1 - 1.0/6},
+			{User: users[1], Score: 1 - 1.0/6},
+			{User: users[3], Score: 1 - 1.0/6},
+		})
+		td.Cmp(t, next, "")
+
+		// "Brina" is two edits away from "Brianna".
+		got, _, err = UserSvc{}.SearchNameFuzzy(txn, "Brianna", distance(1), Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []Match{{User: users[0], Score: 1}, {User: users[3], Score: 1}})
+
+		// A distance of 0 only finds the names that contain the query.
+		got, _, err = UserSvc{}.SearchNameFuzzy(txn, "Brinna", distance(0), Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Nil())
+		got, _, err = UserSvc{}.SearchNameFuzzy(txn, "Brian", distance(0), Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []Match{{User: users[0], Score: 1}, {User: users[3], Score: 1}})
+	})
+
+	t.Run("should go through all the matches page by page", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, next, err := UserSvc{}.SearchNameFuzzy(txn, "Brianna", distance(2), Page{Size: 2})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []Match{{User: users[0], Score: 1}, {User: users[3], Score: 1}})
+		td.CmpNot(t, next, "")
+
+		got, next, err = UserSvc{}.SearchNameFuzzy(txn, "Brianna", distance(2), Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []Match{{User: users[1], Score: 1 - 2.0/7}})
+		td.Cmp(t, next, "")
+	})
+
+	t.Run("should sort the matches using OrderBy when given", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, _, err := UserSvc{}.SearchNameFuzzy(txn, "Brianna", distance(2), Page{OrderBy: []SortKey{{Field: SortAge}}})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []Match{{User: users[1], Score: 1 - 2.0/7}, {User: users[3], Score: 1}, {User: users[0], Score: 1}})
+	})
+
+	t.Run("should not match everything when the query is shorter than the distance", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, _, err := UserSvc{}.SearchNameFuzzy(txn, "qz", distance(5), Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Nil())
+	})
+
+	t.Run("should return an error when the max distance is negative", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		_, _, err := UserSvc{}.SearchNameFuzzy(txn, "Brinna", distance(-1), Page{})
+		td.Cmp(t, err, MaxDistanceNegative)
+	})
+
+	t.Run("should return an error when the query is empty", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		_, _, err := UserSvc{}.SearchNameFuzzy(txn, "", nil, Page{})
+		td.Cmp(t, err, NameQueryEmpty)
+	})
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/rs/xid"
+)
+
+var (
+	GroupNotFound      = errors.New("group not found")
+	GroupNameEmpty     = errors.New("the group name cannot be empty")
+	GroupAlreadyExists = errors.New("group already exists")
+	AlreadyMember      = errors.New("the user is already a member of the group")
+	NotMember          = errors.New("the user is not a member of the group")
+)
+
+// Group is a set of users such as a team or a cohort.
+type Group struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// The IDs of the members, which is why changing the email of a user
+	// does not change its groups. The soft-deleted users stay in their
+	// groups so that Restore brings them back, but they are left out of
+	// the groups returned by GroupSvc; Purge removes them for good.
+	Members []string `json:"members,omitempty"`
+}
+
+// Like UserSvc, this struct is meant to make the service mockable.
+type GroupSvc struct {
+	// Clock gives the creation time of the groups. When nil, time.Now is
+	// used.
+	Clock func() time.Time
+}
+
+func (svc GroupSvc) now() time.Time {
+	if svc.Clock == nil {
+		return time.Now()
+	}
+	return svc.Clock()
+}
+
+// CreateGroup creates a group without members. The ID is generated and
+// CreatedAt is set to the current time. The transaction must be created
+// with write mode.
+//
+// Possible errors: GroupNameEmpty, GroupAlreadyExists.
+func (svc GroupSvc) CreateGroup(txn *memdb.Txn, group Group) (Group, error) {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return Group{}, GroupNameEmpty
+	}
+
+	raw, err := txn.First("group", "name", group.Name)
+	if err != nil {
+		return Group{}, fmt.Errorf("finding if the group %s already exists: %w", group.Name, err)
+	}
+	if raw != nil {
+		return Group{}, GroupAlreadyExists
+	}
+
+	group.ID = xid.New().String()
+	group.CreatedAt = svc.now()
+	group.Members = nil
+	err = txn.Insert("group", &group)
+	if err != nil {
+		return Group{}, fmt.Errorf("inserting group %s: %w", group.Name, err)
+	}
+
+	return group, nil
+}
+
+// DeleteGroup deletes a group by its name. The members are left
+// untouched. The transaction must be created with write mode.
+//
+// Possible errors: GroupNotFound.
+func (GroupSvc) DeleteGroup(txn *memdb.Txn, name string) (Group, error) {
+	group, err := findGroup(txn, name)
+	if err != nil {
+		return Group{}, err
+	}
+
+	err = txn.Delete("group", group)
+	if err != nil {
+		return Group{}, fmt.Errorf("deleting group %s: %w", name, err)
+	}
+
+	return activeMembers(txn, *group)
+}
+
+// GetGroup returns a group by its name, which is case-insensitive.
+//
+// Possible errors: GroupNotFound.
+func (GroupSvc) GetGroup(txn *memdb.Txn, name string) (Group, error) {
+	group, err := findGroup(txn, name)
+	if err != nil {
+		return Group{}, err
+	}
+	return activeMembers(txn, *group)
+}
+
+// ListGroups returns all the groups sorted by name.
+func (GroupSvc) ListGroups(txn *memdb.Txn) ([]Group, error) {
+	it, err := txn.Get("group", "name")
+	if err != nil {
+		return nil, fmt.Errorf("listing groups: %w", err)
+	}
+
+	var groups []Group
+	for raw := it.Next(); raw != nil; raw = it.Next() {
+		group, err := activeMembers(txn, *raw.(*Group))
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// AddMember adds the user that has the given email to the group. The
+// transaction must be created with write mode.
+//
+// Possible errors: GroupNotFound, EmailNotFound, AlreadyMember.
+func (GroupSvc) AddMember(txn *memdb.Txn, name, email string) (Group, error) {
+	group, err := findGroup(txn, name)
+	if err != nil {
+		return Group{}, err
+	}
+	user, err := firstActive(txn, "email", email)
+	if err != nil {
+		return Group{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if user == nil {
+		return Group{}, EmailNotFound
+	}
+
+	for _, id := range group.Members {
+		if id == user.ID {
+			return Group{}, AlreadyMember
+		}
+	}
+
+	// Objects stored in memdb must never be modified in place, which is
+	// why the members are copied.
+	updated := *group
+	updated.Members = append(append([]string(nil), group.Members...), user.ID)
+	err = txn.Insert("group", &updated)
+	if err != nil {
+		return Group{}, fmt.Errorf("adding %s to group %s: %w", email, name, err)
+	}
+
+	return activeMembers(txn, updated)
+}
+
+// RemoveMember removes the user that has the given email from the group.
+// The transaction must be created with write mode.
+//
+// Possible errors: GroupNotFound, EmailNotFound, NotMember.
+func (GroupSvc) RemoveMember(txn *memdb.Txn, name, email string) (Group, error) {
+	group, err := findGroup(txn, name)
+	if err != nil {
+		return Group{}, err
+	}
+	user, err := firstActive(txn, "email", email)
+	if err != nil {
+		return Group{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if user == nil {
+		return Group{}, EmailNotFound
+	}
+
+	updated, ok := withoutMember(*group, user.ID)
+	if !ok {
+		return Group{}, NotMember
+	}
+	err = txn.Insert("group", &updated)
+	if err != nil {
+		return Group{}, fmt.Errorf("removing %s from group %s: %w", email, name, err)
+	}
+
+	return activeMembers(txn, updated)
+}
+
+// Members returns the members of the group sorted by email.
+//
+// Possible errors: GroupNotFound.
+func (GroupSvc) Members(txn *memdb.Txn, name string) ([]User, error) {
+	group, err := findGroup(txn, name)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]User, 0, len(group.Members))
+	for _, id := range group.Members {
+		user, err := firstActive(txn, "id", id)
+		if err != nil {
+			return nil, fmt.Errorf("finding the member %s of group %s: %w", id, name, err)
+		}
+		if user != nil {
+			users = append(users, *user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Email) < strings.ToLower(users[j].Email)
+	})
+
+	return users, nil
+}
+
+// GroupsOf returns the groups of the user that has the given email,
+// sorted by name.
+//
+// Possible errors: EmailNotFound.
+func (GroupSvc) GroupsOf(txn *memdb.Txn, email string) ([]Group, error) {
+	user, err := firstActive(txn, "email", email)
+	if err != nil {
+		return nil, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if user == nil {
+		return nil, EmailNotFound
+	}
+
+	groups, err := groupsOf(txn, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Group, 0, len(groups))
+	for _, g := range groups {
+		group, err := activeMembers(txn, *g)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, group)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return strings.ToLower(res[i].Name) < strings.ToLower(res[j].Name)
+	})
+
+	return res, nil
+}
+
+// leaveGroups removes the user from all its groups. It is used when the
+// user is purged.
+func leaveGroups(txn *memdb.Txn, userID string) error {
+	groups, err := groupsOf(txn, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		updated, _ := withoutMember(*g, userID)
+		err = txn.Insert("group", &updated)
+		if err != nil {
+			return fmt.Errorf("removing %s from group %s: %w", userID, g.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// groupsOf reads all the groups at once since the groups cannot be
+// modified while the member index is being iterated over.
+func groupsOf(txn *memdb.Txn, userID string) ([]*Group, error) {
+	it, err := txn.Get("group", "member", userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding the groups of %s: %w", userID, err)
+	}
+
+	var groups []*Group
+	for raw := it.Next(); raw != nil; raw = it.Next() {
+		groups = append(groups, raw.(*Group))
+	}
+
+	return groups, nil
+}
+
+func findGroup(txn *memdb.Txn, name string) (*Group, error) {
+	raw, err := txn.First("group", "name", strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("finding the group %s: %w", name, err)
+	}
+	if raw == nil {
+		return nil, GroupNotFound
+	}
+	return raw.(*Group), nil
+}
+
+// activeMembers returns a copy of the group without the members that are
+// soft-deleted.
+func activeMembers(txn *memdb.Txn, group Group) (Group, error) {
+	var members []string
+	for _, id := range group.Members {
+		user, err := firstActive(txn, "id", id)
+		if err != nil {
+			return Group{}, fmt.Errorf("finding the member %s of group %s: %w", id, group.Name, err)
+		}
+		if user != nil {
+			members = append(members, id)
+		}
+	}
+	group.Members = members
+	return group, nil
+}
+
+// withoutMember returns a copy of the group without the given member; ok
+// is false when the user was not a member.
+func withoutMember(group Group, userID string) (_ Group, ok bool) {
+	members := make([]string, 0, len(group.Members))
+	for _, id := range group.Members {
+		if id == userID {
+			ok = true
+			continue
+		}
+		members = append(members, id)
+	}
+	group.Members = members
+	return group, ok
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestGroupSvc(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{ID: "a1", Email: "b@pod.ru"},
+		{ID: "a2", Email: "a@pod.ru"},
+		{ID: "a3", Email: "c@pod.ru", DeletedAt: deletedAt},
+	}
+
+	t.Run("should create, list and delete groups", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+
+		payments, err := GroupSvc{}.CreateGroup(txn, Group{Name: " Payments ", Description: "The payments team"})
+		td.CmpNoError(t, err)
+		td.Cmp(t, payments, td.SStruct(Group{Name: "Payments", Description: "The payments team"}, td.StructFields{
+			"ID":        td.NotEmpty(),
+			"CreatedAt": td.NotZero(),
+		}))
+		_, err = GroupSvc{}.CreateGroup(txn, Group{Name: "admins"})
+		td.CmpNoError(t, err)
+
+		_, err = GroupSvc{}.CreateGroup(txn, Group{Name: "payments"})
+		td.Cmp(t, err, GroupAlreadyExists)
+		_, err = GroupSvc{}.CreateGroup(txn, Group{Name: "  "})
+		td.Cmp(t, err, GroupNameEmpty)
+
+		got, err := GroupSvc{}.ListGroups(txn)
+		td.CmpNoError(t, err)
+		td.Cmp(t, groupNames(got), []string{"admins", "Payments"})
+
+		got1, err := GroupSvc{}.GetGroup(txn, "PAYMENTS")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got1, payments)
+
+		_, err = GroupSvc{}.DeleteGroup(txn, "payments")
+		td.CmpNoError(t, err)
+		_, err = GroupSvc{}.GetGroup(txn, "payments")
+		td.Cmp(t, err, GroupNotFound)
+		_, err = GroupSvc{}.DeleteGroup(txn, "payments")
+		td.Cmp(t, err, GroupNotFound)
+	})
+
+	t.Run("should add and remove members", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, err := GroupSvc{}.CreateGroup(txn, Group{Name: "payments"})
+		td.CmpNoError(t, err)
+		before, err := GroupSvc{}.AddMember(txn, "payments", "b@pod.ru")
+		td.CmpNoError(t, err)
+		got, err := GroupSvc{}.AddMember(txn, "payments", "A@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got.Members, []string{"a1", "a2"})
+		td.Cmp(t, before.Members, []string{"a1"}, "the stored group must not be modified in place")
+
+		_, err = GroupSvc{}.AddMember(txn, "payments", "a@pod.ru")
+		td.Cmp(t, err, AlreadyMember)
+		_, err = GroupSvc{}.AddMember(txn, "payments", "c@pod.ru")
+		td.Cmp(t, err, EmailNotFound)
+		_, err = GroupSvc{}.AddMember(txn, "nobody", "a@pod.ru")
+		td.Cmp(t, err, GroupNotFound)
+
+		members, err := GroupSvc{}.Members(txn, "payments")
+		td.CmpNoError(t, err)
+		td.Cmp(t, members, []User{users[1], users[0]})
+
+		got, err = GroupSvc{}.RemoveMember(txn, "payments", "b@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got.Members, []string{"a2"})
+		_, err = GroupSvc{}.RemoveMember(txn, "payments", "b@pod.ru")
+		td.Cmp(t, err, NotMember)
+	})
+
+	t.Run("should find the groups of a user", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		for _, name := range []string{"search", "admins", "payments"} {
+			_, err := GroupSvc{}.CreateGroup(txn, Group{Name: name})
+			td.CmpNoError(t, err)
+		}
+		for _, name := range []string{"search", "admins"} {
+			_, err := GroupSvc{}.AddMember(txn, name, "a@pod.ru")
+			td.CmpNoError(t, err)
+		}
+
+		got, err := GroupSvc{}.GroupsOf(txn, "a@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, groupNames(got), []string{"admins", "search"})
+
+		got, err = GroupSvc{}.GroupsOf(txn, "b@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Empty())
+
+		_, err = GroupSvc{}.GroupsOf(txn, "c@pod.ru")
+		td.Cmp(t, err, EmailNotFound)
+	})
+
+	t.Run("should hide deleted users from their groups until they are restored", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		for _, name := range []string{"search", "admins"} {
+			_, err := GroupSvc{}.CreateGroup(txn, Group{Name: name})
+			td.CmpNoError(t, err)
+			_, err = GroupSvc{}.AddMember(txn, name, "a@pod.ru")
+			td.CmpNoError(t, err)
+			_, err = GroupSvc{}.AddMember(txn, name, "b@pod.ru")
+			td.CmpNoError(t, err)
+		}
+
+		_, err := UserSvc{}.Delete(txn, "a@pod.ru", "", "")
+		td.CmpNoError(t, err)
+
+		for _, name := range []string{"search", "admins"} {
+			got, err := GroupSvc{}.GetGroup(txn, name)
+			td.CmpNoError(t, err)
+			td.Cmp(t, got.Members, []string{"a1"})
+			members, err := GroupSvc{}.Members(txn, name)
+			td.CmpNoError(t, err)
+			td.Cmp(t, members, []User{users[0]})
+		}
+
+		_, err = UserSvc{}.Restore(txn, "a@pod.ru", "")
+		td.CmpNoError(t, err)
+		got, err := GroupSvc{}.GroupsOf(txn, "a@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, groupNames(got), []string{"admins", "search"}, "the memberships are restored")
+		group, err := GroupSvc{}.GetGroup(txn, "search")
+		td.CmpNoError(t, err)
+		td.Cmp(t, group.Members, []string{"a2", "a1"})
+	})
+
+	t.Run("should remove purged users from their groups", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, err := GroupSvc{}.CreateGroup(txn, Group{Name: "search"})
+		td.CmpNoError(t, err)
+		_, err = GroupSvc{}.AddMember(txn, "search", "a@pod.ru")
+		td.CmpNoError(t, err)
+		_, err = UserSvc{}.Delete(txn, "a@pod.ru", "", "")
+		td.CmpNoError(t, err)
+		_, err = UserSvc{}.Purge(txn, "a@pod.ru", "")
+		td.CmpNoError(t, err)
+
+		raw, err := txn.First("group", "name", "search")
+		td.CmpNoError(t, err)
+		td.Cmp(t, raw.(*Group).Members, td.Empty())
+	})
+}
+
+func groupNames(groups []Group) []string {
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names
+}
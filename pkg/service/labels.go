@@ -0,0 +1,253 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/maelvls/users-grpc/pkg/userfields"
+)
+
+var (
+	LabelInvalid    = userfields.LabelInvalid
+	SelectorInvalid = errors.New("invalid label selector")
+)
+
+// copyLabels is used before modifying the labels of a user that is stored
+// in memdb since the map would otherwise be shared.
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	res := make(map[string]string, len(labels))
+	for k, v := range labels {
+		res[k] = v
+	}
+	return res
+}
+
+type SelectorOp string
+
+const (
+	Equals       SelectorOp = "="
+	NotEquals    SelectorOp = "!="
+	In           SelectorOp = "in"
+	NotIn        SelectorOp = "notin"
+	Exists       SelectorOp = "exists"
+	DoesNotExist SelectorOp = "!"
+)
+
+// Requirement is a single condition of a Selector, e.g. "team=payments"
+// or "plan in (pro,team)". Equals and NotEquals have a single value while
+// Exists and DoesNotExist have none.
+type Requirement struct {
+	Key    string
+	Op     SelectorOp
+	Values []string
+}
+
+// Selector selects the users whose labels match all its requirements. The
+// zero Selector matches every user.
+type Selector []Requirement
+
+// ParseSelector parses a label selector written the same way as the
+// Kubernetes label selectors, e.g.
+//
+//	team=payments,plan!=free    equality and inequality
+//	plan in (pro,team)          set-based requirements
+//	plan notin (free)
+//	team,!deprecated            existence
+//
+// The requirements are separated by commas. A user matches "!=" and
+// "notin" when it does not have the label at all.
+//
+// Possible errors: SelectorInvalid.
+func ParseSelector(s string) (Selector, error) {
+	var sel Selector
+	for _, part := range splitSelector(s) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("%w: '%s' contains an empty requirement", SelectorInvalid, s)
+		}
+
+		req, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, req)
+	}
+
+	return sel, nil
+}
+
+// splitSelector splits on the commas that are not between parentheses.
+func splitSelector(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+var setRequirement = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(([^()]*)\)$`)
+
+func parseRequirement(s string) (Requirement, error) {
+	var req Requirement
+	switch {
+	case setRequirement.MatchString(s):
+		m := setRequirement.FindStringSubmatch(s)
+		req = Requirement{Key: m[1], Op: SelectorOp(m[2])}
+		for _, v := range strings.Split(m[3], ",") {
+			req.Values = append(req.Values, strings.TrimSpace(v))
+		}
+	case strings.Contains(s, "!="):
+		kv := strings.SplitN(s, "!=", 2)
+		req = Requirement{Key: strings.TrimSpace(kv[0]), Op: NotEquals, Values: []string{strings.TrimSpace(kv[1])}}
+	case strings.Contains(s, "="):
+		kv := strings.SplitN(s, "=", 2)
+		// Both "=" and "==" mean the same thing.
+		req = Requirement{Key: strings.TrimSpace(kv[0]), Op: Equals, Values: []string{strings.TrimSpace(strings.TrimPrefix(kv[1], "="))}}
+	case strings.HasPrefix(s, "!"):
+		req = Requirement{Key: strings.TrimSpace(s[1:]), Op: DoesNotExist}
+	default:
+		req = Requirement{Key: s, Op: Exists}
+	}
+
+	if userfields.ValidateLabelKey(req.Key) != nil {
+		return Requirement{}, fmt.Errorf("%w: '%s' has an invalid key", SelectorInvalid, s)
+	}
+	for _, v := range req.Values {
+		if userfields.ValidateLabelValue(v) != nil {
+			return Requirement{}, fmt.Errorf("%w: '%s' has an invalid value '%s'", SelectorInvalid, s, v)
+		}
+	}
+
+	return req, nil
+}
+
+func (req Requirement) matches(labels map[string]string) bool {
+	value, ok := labels[req.Key]
+	switch req.Op {
+	case Equals:
+		return ok && value == req.Values[0]
+	case NotEquals:
+		return !ok || value != req.Values[0]
+	case In:
+		return ok && contains(req.Values, value)
+	case NotIn:
+		return !ok || !contains(req.Values, value)
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matcher returns a function that tells whether the labels of a user
+// match all the requirements of the selector.
+func (sel Selector) matcher() func(*User) bool {
+	return func(u *User) bool {
+		for _, req := range sel {
+			if !req.matches(u.Labels) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// labelCandidates uses the label index for finding the users that may
+// match the selector. The first requirement that needs the user to have
+// the label is used; ok is false when there is no such requirement, e.g.
+// for "team!=payments". The users are sorted by email.
+func labelCandidates(txn *memdb.Txn, sel Selector) (_ []*User, ok bool, _ error) {
+	for _, req := range sel {
+		var lookups [][]interface{}
+		switch req.Op {
+		case Equals, In:
+			for _, v := range req.Values {
+				lookups = append(lookups, []interface{}{req.Key, v})
+			}
+		case Exists:
+			lookups = append(lookups, []interface{}{req.Key})
+		default:
+			continue
+		}
+
+		found := make(map[string]*User)
+		for _, args := range lookups {
+			it, err := txn.Get("user", "label", args...)
+			if err != nil {
+				return nil, false, err
+			}
+			for raw := it.Next(); raw != nil; raw = it.Next() {
+				found[raw.(*User).ID] = raw.(*User)
+			}
+		}
+
+		users := make([]*User, 0, len(found))
+		for _, u := range found {
+			users = append(users, u)
+		}
+		sort.Slice(users, func(i, j int) bool { return strings.ToLower(users[i].Email) < strings.ToLower(users[j].Email) })
+
+		return users, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// walkLabels goes through the users whose labels match the selector,
+// sorted by email and starting at the given email. The label index is used
+// for narrowing down the users to go through; when the selector has no
+// requirement that can use it, the whole email index is walked instead.
+func walkLabels(txn *memdb.Txn, sel Selector, fromEmail string, fn func(*User) bool) error {
+	fn = filter(sel.matcher(), fn)
+
+	candidates, ok, err := labelCandidates(txn, sel)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return walkEmail(txn, fromEmail, fn)
+	}
+
+	for _, u := range candidates {
+		if strings.ToLower(u.Email) < strings.ToLower(fromEmail) {
+			continue
+		}
+		if !fn(u) {
+			break
+		}
+	}
+
+	return nil
+}
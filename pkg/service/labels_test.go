@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		given   string
+		want    Selector
+		wantErr error
+	}{
+		{given: "", want: nil},
+		{given: "team=payments", want: Selector{{Key: "team", Op: Equals, Values: []string{"payments"}}}},
+		{given: "team==payments", want: Selector{{Key: "team", Op: Equals, Values: []string{"payments"}}}},
+		{given: "team != payments", want: Selector{{Key: "team", Op: NotEquals, Values: []string{"payments"}}}},
+		{given: "plan in (pro, team),team", want: Selector{
+			{Key: "plan", Op: In, Values: []string{"pro", "team"}},
+			{Key: "team", Op: Exists},
+		}},
+		{given: "plan notin (free),!deprecated", want: Selector{
+			{Key: "plan", Op: NotIn, Values: []string{"free"}},
+			{Key: "deprecated", Op: DoesNotExist},
+		}},
+		{given: "example.com/team=payments", want: Selector{{Key: "example.com/team", Op: Equals, Values: []string{"payments"}}}},
+		{given: "team=", want: Selector{{Key: "team", Op: Equals, Values: []string{""}}}},
+		{given: "team=payments,", wantErr: SelectorInvalid},
+		{given: "=payments", wantErr: SelectorInvalid},
+		{given: "team=pay ments", wantErr: SelectorInvalid},
+		{given: "plan in pro", wantErr: SelectorInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			got, err := ParseSelector(tt.given)
+			if tt.wantErr != nil {
+				td.CmpTrue(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			td.CmpNoError(t, err)
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
+
+func TestSelector_labels(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{ID: "a1", Email: "a@pod.ru", Labels: map[string]string{"team": "payments", "plan": "pro"}},
+		{ID: "a2", Email: "b@pod.ru", Labels: map[string]string{"team": "search", "plan": "free"}},
+		{ID: "a3", Email: "c@pod.ru", Labels: map[string]string{"plan": "team"}},
+		{ID: "a4", Email: "d@pod.ru"},
+		{ID: "a5", Email: "e@pod.ru", Labels: map[string]string{"team": "payments"}, DeletedAt: deletedAt},
+		{ID: "a6", Email: "f@email.org", Labels: map[string]string{"team": "payments"}},
+	}
+
+	tests := []struct {
+		selector string
+		want     []User
+	}{
+		{selector: "team=payments", want: []User{users[0], users[5]}},
+		{selector: "team!=payments", want: []User{users[1], users[2], users[3]}},
+		{selector: "plan in (pro,team)", want: []User{users[0], users[2]}},
+		{selector: "plan notin (pro,team)", want: []User{users[1], users[3], users[5]}},
+		{selector: "team", want: []User{users[0], users[1], users[5]}},
+		{selector: "!team", want: []User{users[2], users[3]}},
+		{selector: "team,plan=pro", want: []User{users[0]}},
+		{selector: "team=nobody", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run("List "+tt.selector, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+			fillDBWith(users)(txn)
+
+			sel, err := ParseSelector(tt.selector)
+			td.CmpNoError(t, err)
+
+			got, _, err := UserSvc{}.List(txn, false, TimeRange{}, sel, Page{})
+			td.CmpNoError(t, err)
+			td.Cmp(t, got, tt.want)
+		})
+	}
+
+	t.Run("Search should combine the selector with the other criteria", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		sel, err := ParseSelector("team=payments")
+		td.CmpNoError(t, err)
+
+		got, _, err := UserSvc{}.Search(txn, SearchQuery{EmailDomain: "pod.ru", Selector: sel}, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[0]})
+
+		got, _, err = UserSvc{}.Search(txn, SearchQuery{Selector: sel, IncludeDeleted: true}, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[0], users[4], users[5]})
+	})
+
+	t.Run("List should go through the pages", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		sel, err := ParseSelector("team")
+		td.CmpNoError(t, err)
+
+		got, next, err := UserSvc{}.List(txn, false, TimeRange{}, sel, Page{Size: 2})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[0], users[1]})
+
+		got, next, err = UserSvc{}.List(txn, false, TimeRange{}, sel, Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[5]})
+		td.Cmp(t, next, "")
+	})
+}
+
+func TestUpdate_labels(t *testing.T) {
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	defer txn.Abort()
+
+	td.CmpNoError(t, UserSvc{}.Create(txn, User{ID: "a1", Email: "a@pod.ru", Labels: map[string]string{"team": "payments", "plan": "pro"}}))
+	before, err := UserSvc{}.GetByEmail(txn, "a@pod.ru", false)
+	td.CmpNoError(t, err)
+
+	got, err := UserSvc{}.Update(txn, "a@pod.ru", []string{"labels.plan", "labels.tier", "labels.team"}, User{Labels: map[string]string{"tier": "gold", "team": "search"}}, "")
+	td.CmpNoError(t, err)
+	td.Cmp(t, got.Labels, map[string]string{"team": "search", "tier": "gold"})
+	td.Cmp(t, before.Labels, map[string]string{"team": "payments", "plan": "pro"}, "the stored user must not be modified in place")
+
+	got, err = UserSvc{}.Update(txn, "a@pod.ru", []string{"labels"}, User{Labels: map[string]string{"plan": "free"}}, "")
+	td.CmpNoError(t, err)
+	td.Cmp(t, got.Labels, map[string]string{"plan": "free"})
+
+	_, err = UserSvc{}.Update(txn, "a@pod.ru", []string{"labels.team"}, User{Labels: map[string]string{"team": "a b"}}, "")
+	td.CmpTrue(t, errors.Is(err, LabelInvalid))
+
+	err = UserSvc{}.Create(txn, User{ID: "a2", Email: "b@pod.ru", Labels: map[string]string{"": "payments"}})
+	td.CmpTrue(t, errors.Is(err, LabelInvalid))
+}
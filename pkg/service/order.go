@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+var OrderByInvalid = errors.New("invalid order by field")
+
+// SortField is a field that users can be sorted by.
+type SortField int
+
+const (
+	SortEmail SortField = iota
+	SortFirstName
+	SortLastName
+	SortAge
+)
+
+// SortKey is one of the keys used for sorting users. When several keys
+// are given, the second key is used when the first one is equal, and so
+// on.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+func validateOrderBy(keys []SortKey) error {
+	for _, key := range keys {
+		if key.Field < SortEmail || key.Field > SortAge {
+			return OrderByInvalid
+		}
+	}
+	return nil
+}
+
+// sortUsers sorts the users using the given keys. Names are compared
+// using the Unicode collation algorithm so that "Élodie" sorts right
+// after "Elodie" instead of after "Zoe". The email is used as a last
+// resort so that the order is always the same.
+func sortUsers(users []User, keys []SortKey) {
+	cmp := newComparator(keys)
+	sort.SliceStable(users, func(i, j int) bool {
+		return cmp(&users[i], &users[j]) < 0
+	})
+}
+
+// newComparator returns a function that returns -1 when a sorts before b,
+// 1 when a sorts after b and 0 when they are equal. The returned function
+// must not be used concurrently since the collator keeps a buffer.
+func newComparator(keys []SortKey) func(a, b *User) int {
+	col := collate.New(language.Und)
+
+	return func(a, b *User) int {
+		for _, key := range keys {
+			var c int
+			switch key.Field {
+			case SortEmail:
+				c = compareStrings(strings.ToLower(a.Email), strings.ToLower(b.Email))
+			case SortFirstName:
+				c = col.CompareString(a.FirstName, b.FirstName)
+			case SortLastName:
+				c = col.CompareString(a.LastName, b.LastName)
+			case SortAge:
+				c = compareInts(a.Age, b.Age)
+			}
+			if key.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+
+		// Emails are unique regardless of their case, which means that
+		// two different users are never equal.
+		return compareStrings(strings.ToLower(a.Email), strings.ToLower(b.Email))
+	}
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(a, b int32) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestSortUsers(t *testing.T) {
+	tests := []struct {
+		name  string
+		given []User
+		keys  []SortKey
+		want  []string // Emails.
+	}{
+		{
+			name: "names with diacritics should sort next to their ASCII counterparts",
+			given: []User{
+				{FirstName: "Zoe", Email: "zoe@pod.ru"},
+				{FirstName: "Élodie", Email: "elodie2@pod.ru"},
+				{FirstName: "Elodie", Email: "elodie1@pod.ru"},
+				{FirstName: "Emma", Email: "emma@pod.ru"},
+			},
+			keys: []SortKey{{Field: SortFirstName}},
+			want: []string{"elodie1@pod.ru", "elodie2@pod.ru", "emma@pod.ru", "zoe@pod.ru"},
+		},
+		{
+			name: "the second key is used when the first keys are equal",
+			given: []User{
+				{LastName: "Hale", Age: 21, Email: "a@pod.ru"},
+				{LastName: "Keller", Age: 42, Email: "b@pod.ru"},
+				{LastName: "Hale", Age: 38, Email: "c@pod.ru"},
+			},
+			keys: []SortKey{{Field: SortLastName}, {Field: SortAge, Descending: true}},
+			want: []string{"c@pod.ru", "a@pod.ru", "b@pod.ru"},
+		},
+		{
+			name: "the email is used when all the keys are equal",
+			given: []User{
+				{Age: 21, Email: "c@pod.ru"},
+				{Age: 21, Email: "a@pod.ru"},
+				{Age: 21, Email: "b@pod.ru"},
+			},
+			keys: []SortKey{{Field: SortAge}},
+			want: []string{"a@pod.ru", "b@pod.ru", "c@pod.ru"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortUsers(tt.given, tt.keys)
+
+			var got []string
+			for _, u := range tt.given {
+				got = append(got, u.Email)
+			}
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+		{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+	}
+	byAgeDesc := []SortKey{{Field: SortAge, Descending: true}}
+
+	t.Run("List should go through the sorted users page by page", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, next, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Size: 2, OrderBy: byAgeDesc})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[1], users[2]})
+
+		// The page must not shift when a user that sorts before the
+		// cursor is created in between.
+		td.CmpNoError(t, UserSvc{}.Create(txn, User{ID: "0a1b2c3", Email: "old@pod.ru", Age: 99}))
+
+		got, next, err = UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Size: 2, Token: next, OrderBy: byAgeDesc})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[0]})
+		td.Cmp(t, next, "")
+	})
+
+	t.Run("SearchName should return the sorted users", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, _, err := UserSvc{}.SearchName(txn, "l", Page{OrderBy: []SortKey{{Field: SortLastName}}})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[2], users[1], users[0]})
+	})
+
+	t.Run("should return an error when the field is unknown", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		_, _, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{OrderBy: []SortKey{{Field: -1}}})
+		td.Cmp(t, err, OrderByInvalid)
+	})
+}
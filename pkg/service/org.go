@@ -0,0 +1,261 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+var (
+	ManagerNotFound = errors.New("manager not found")
+	ManagerCycle    = errors.New("the manager would create a reporting cycle")
+)
+
+// OrgNode is a user along with the users that report to it, see OrgTree.
+type OrgNode struct {
+	User    User
+	Reports []OrgNode
+
+	// Truncated is true when the user has reports that were left out
+	// because of the depth limit.
+	Truncated bool
+}
+
+// checkManager makes sure that the manager of the user exists and that
+// the user is not one of the managers of its manager, which would make
+// the reporting chain loop. A soft-deleted user cannot become a manager,
+// but it stays the manager of its reports until it is purged; previous is
+// the manager the user had before, if any. The soft-deleted managers are
+// part of the chain since restoring them could otherwise make it loop.
+//
+// Possible errors: ManagerNotFound, ManagerCycle.
+func checkManager(txn *memdb.Txn, user *User, previous string) error {
+	if user.ManagerID == "" {
+		return nil
+	}
+
+	seen := map[string]bool{user.ID: true}
+	for id := user.ManagerID; id != ""; {
+		if seen[id] {
+			return ManagerCycle
+		}
+		seen[id] = true
+
+		raw, err := txn.First("user", "id", id)
+		if err != nil {
+			return fmt.Errorf("finding the manager %s: %w", id, err)
+		}
+		if raw == nil {
+			if id == user.ManagerID {
+				return ManagerNotFound
+			}
+			// The chain above the manager was checked when it was
+			// written, no need to go further.
+			return nil
+		}
+		manager := raw.(*User)
+		if id == user.ManagerID && manager.Deleted() && id != previous {
+			return ManagerNotFound
+		}
+		id = manager.ManagerID
+	}
+
+	return nil
+}
+
+// walkReports goes through the users, including the soft-deleted ones,
+// that directly report to the given manager, sorted by email.
+func walkReports(txn *memdb.Txn, managerID string, fn func(*User) bool) error {
+	it, err := txn.LowerBound("user", "manager", managerID, "")
+	if err != nil {
+		return err
+	}
+
+	for raw := it.Next(); raw != nil; raw = it.Next() {
+		u := raw.(*User)
+		if u.ManagerID != managerID {
+			break
+		}
+		if !fn(u) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// reassignReports makes the direct reports of a user that is being
+// purged report to the manager of this user instead, which means that a
+// manager is never a purged user. The reports are updated at the given
+// time.
+func reassignReports(txn *memdb.Txn, purged *User, at time.Time) error {
+	// The users cannot be written while the index is being iterated over.
+	var reports []*User
+	err := walkReports(txn, purged.ID, func(u *User) bool {
+		reports = append(reports, u)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("finding the reports of %s: %w", purged.Email, err)
+	}
+
+	for _, u := range reports {
+		updated := *u
+		updated.ManagerID = purged.ManagerID
+		touch(&updated, at)
+		err = txn.Insert("user", &updated)
+		if err != nil {
+			return fmt.Errorf("changing the manager of %s: %w", u.Email, err)
+		}
+	}
+
+	return nil
+}
+
+// activeReports returns the users that report to the given manager and
+// that are not soft-deleted, sorted by email. The reports of a
+// soft-deleted report are returned in its place, the same way as they
+// would be once it is purged.
+func activeReports(txn *memdb.Txn, managerID string) ([]*User, error) {
+	var reports, deleted []*User
+	err := walkReports(txn, managerID, func(u *User) bool {
+		if u.Deleted() {
+			deleted = append(deleted, u)
+		} else {
+			reports = append(reports, u)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(deleted) == 0 {
+		return reports, nil
+	}
+
+	for _, u := range deleted {
+		below, err := activeReports(txn, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, below...)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return strings.ToLower(reports[i].Email) < strings.ToLower(reports[j].Email)
+	})
+
+	return reports, nil
+}
+
+// DirectReports returns the users that directly report to the user that
+// has the given email, sorted by email. The soft-deleted users are not
+// returned, and their reports are returned instead.
+//
+// Possible errors: EmailNotFound.
+func (UserSvc) DirectReports(txn *memdb.Txn, email string) ([]User, error) {
+	manager, err := firstActive(txn, "email", email)
+	if err != nil {
+		return nil, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if manager == nil {
+		return nil, EmailNotFound
+	}
+
+	reports, err := activeReports(txn, manager.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding the reports of %s: %w", email, err)
+	}
+
+	users := make([]User, 0, len(reports))
+	for _, u := range reports {
+		users = append(users, *u)
+	}
+
+	return users, nil
+}
+
+// ReportingChain returns the managers of the user that has the given
+// email, starting with its direct manager and ending with the user that
+// has no manager. It is empty when the user has no manager. The
+// soft-deleted managers are skipped.
+//
+// Possible errors: EmailNotFound.
+func (UserSvc) ReportingChain(txn *memdb.Txn, email string) ([]User, error) {
+	user, err := firstActive(txn, "email", email)
+	if err != nil {
+		return nil, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if user == nil {
+		return nil, EmailNotFound
+	}
+
+	var chain []User
+	for id := user.ManagerID; id != ""; {
+		raw, err := txn.First("user", "id", id)
+		if err != nil {
+			return nil, fmt.Errorf("finding the manager %s: %w", id, err)
+		}
+		if raw == nil {
+			break
+		}
+		manager := raw.(*User)
+		if !manager.Deleted() {
+			chain = append(chain, *manager)
+		}
+		id = manager.ManagerID
+	}
+
+	return chain, nil
+}
+
+// OrgTree returns the user that has the given email along with the users
+// that report to it, directly or not. When maxDepth is positive, only the
+// users that are at most maxDepth levels below the given user are
+// returned, e.g. 1 only returns the direct reports. The reports are
+// sorted by email and the soft-deleted users are left out, their reports
+// taking their place.
+//
+// Possible errors: EmailNotFound.
+func (UserSvc) OrgTree(txn *memdb.Txn, email string, maxDepth int) (OrgNode, error) {
+	root, err := firstActive(txn, "email", email)
+	if err != nil {
+		return OrgNode{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if root == nil {
+		return OrgNode{}, EmailNotFound
+	}
+
+	if maxDepth <= 0 {
+		maxDepth = -1
+	}
+	return orgTree(txn, root, maxDepth)
+}
+
+// orgTree builds the tree recursively; depth is the number of levels that
+// can still be added below the user, or a negative value for no limit.
+func orgTree(txn *memdb.Txn, user *User, depth int) (OrgNode, error) {
+	node := OrgNode{User: *user}
+
+	reports, err := activeReports(txn, user.ID)
+	if err != nil {
+		return OrgNode{}, fmt.Errorf("finding the reports of %s: %w", user.Email, err)
+	}
+
+	if depth == 0 {
+		node.Truncated = len(reports) > 0
+		return node, nil
+	}
+	for _, u := range reports {
+		child, err := orgTree(txn, u, depth-1)
+		if err != nil {
+			return OrgNode{}, err
+		}
+		node.Reports = append(node.Reports, child)
+	}
+
+	return node, nil
+}
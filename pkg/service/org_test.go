@@ -0,0 +1,176 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestOrg(t *testing.T) {
+	db := NewDBOrPanic()
+	// ceo
+	// ├── b (cto)
+	// │   ├── d
+	// │   │   └── f
+	// │   └── e
+	// └── c
+	users := []User{
+		{ID: "a1", Email: "ceo@pod.ru"},
+		{ID: "a2", Email: "b@pod.ru", ManagerID: "a1"},
+		{ID: "a3", Email: "c@pod.ru", ManagerID: "a1"},
+		{ID: "a4", Email: "d@pod.ru", ManagerID: "a2"},
+		{ID: "a5", Email: "e@pod.ru", ManagerID: "a2"},
+		{ID: "a6", Email: "f@pod.ru", ManagerID: "a4"},
+		{ID: "a7", Email: "g@pod.ru", ManagerID: "a2", DeletedAt: deletedAt},
+	}
+
+	t.Run("DirectReports should return the reports sorted by email", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, err := UserSvc{}.DirectReports(txn, "b@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[3], users[4]})
+
+		got, err = UserSvc{}.DirectReports(txn, "f@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Empty())
+
+		_, err = UserSvc{}.DirectReports(txn, "g@pod.ru")
+		td.Cmp(t, err, EmailNotFound)
+	})
+
+	t.Run("ReportingChain should go up to the root", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, err := UserSvc{}.ReportingChain(txn, "f@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[3], users[1], users[0]})
+
+		got, err = UserSvc{}.ReportingChain(txn, "ceo@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Empty())
+	})
+
+	t.Run("OrgTree should stop at the depth limit", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, err := UserSvc{}.OrgTree(txn, "ceo@pod.ru", 0)
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, OrgNode{User: users[0], Reports: []OrgNode{
+			{User: users[1], Reports: []OrgNode{
+				{User: users[3], Reports: []OrgNode{{User: users[5]}}},
+				{User: users[4]},
+			}},
+			{User: users[2]},
+		}})
+
+		got, err = UserSvc{}.OrgTree(txn, "ceo@pod.ru", 2)
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, OrgNode{User: users[0], Reports: []OrgNode{
+			{User: users[1], Reports: []OrgNode{
+				{User: users[3], Truncated: true},
+				{User: users[4]},
+			}},
+			{User: users[2]},
+		}})
+
+		_, err = UserSvc{}.OrgTree(txn, "nobody@pod.ru", 0)
+		td.Cmp(t, err, EmailNotFound)
+	})
+
+	t.Run("the manager should exist and not create a cycle", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		err := UserSvc{}.Create(txn, User{Email: "h@pod.ru", ManagerID: "nobody"})
+		td.Cmp(t, err, ManagerNotFound)
+		err = UserSvc{}.Create(txn, User{Email: "h@pod.ru", ManagerID: "a7"})
+		td.Cmp(t, err, ManagerNotFound, "a deleted user cannot be a manager")
+		err = UserSvc{}.Create(txn, User{Email: "h@pod.ru", ManagerID: "a6"})
+		td.CmpNoError(t, err)
+
+		_, err = UserSvc{}.Update(txn, "b@pod.ru", []string{"manager_id"}, User{ManagerID: "a6"}, "")
+		td.Cmp(t, err, ManagerCycle)
+		_, err = UserSvc{}.Update(txn, "b@pod.ru", []string{"manager_id"}, User{ManagerID: "a2"}, "")
+		td.Cmp(t, err, ManagerCycle)
+
+		got, err := UserSvc{}.Update(txn, "d@pod.ru", []string{"manager_id"}, User{ManagerID: "a3"}, "")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got.ManagerID, "a3")
+		got, err = UserSvc{}.Update(txn, "d@pod.ru", []string{"manager_id"}, User{}, "")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got.ManagerID, "")
+	})
+
+	emails := func(users []User) []string {
+		var emails []string
+		for _, u := range users {
+			emails = append(emails, u.Email)
+		}
+		return emails
+	}
+
+	t.Run("a deleted manager should keep its reports until it is restored", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, err := UserSvc{}.Delete(txn, "b@pod.ru", "", "")
+		td.CmpNoError(t, err)
+
+		got, err := UserSvc{}.DirectReports(txn, "ceo@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Smuggle(emails, []string{"c@pod.ru", "d@pod.ru", "e@pod.ru"}),
+			"the reports of the deleted manager are shown in its place")
+		chain, err := UserSvc{}.ReportingChain(txn, "f@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, chain, []User{users[3], users[0]})
+
+		updated, err := UserSvc{}.Update(txn, "d@pod.ru", []string{"age"}, User{Age: 30}, "")
+		td.CmpNoError(t, err, "a user can keep its deleted manager")
+		td.Cmp(t, updated.ManagerID, "a2")
+
+		_, err = UserSvc{}.Restore(txn, "b@pod.ru", "")
+		td.CmpNoError(t, err)
+
+		got, err = UserSvc{}.DirectReports(txn, "b@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Smuggle(emails, []string{"d@pod.ru", "e@pod.ru"}))
+		got, err = UserSvc{}.DirectReports(txn, "ceo@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Smuggle(emails, []string{"b@pod.ru", "c@pod.ru"}))
+	})
+
+	t.Run("the reports of a purged user should report to its manager", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, err := UserSvc{}.Delete(txn, "b@pod.ru", "", "")
+		td.CmpNoError(t, err)
+		_, err = UserSvc{}.Purge(txn, "b@pod.ru", "")
+		td.CmpNoError(t, err)
+
+		got, err := UserSvc{}.DirectReports(txn, "ceo@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Smuggle(emails, []string{"c@pod.ru", "d@pod.ru", "e@pod.ru"}))
+		td.Cmp(t, got, td.Smuggle(func(users []User) []string {
+			var ids []string
+			for _, u := range users {
+				ids = append(ids, u.ManagerID)
+			}
+			return ids
+		}, []string{"a1", "a1", "a1"}))
+
+		deleted, err := UserSvc{}.GetByEmail(txn, "g@pod.ru", true)
+		td.CmpNoError(t, err)
+		td.Cmp(t, deleted.ManagerID, "a1", "the deleted reports are also moved")
+	})
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+var (
+	PageSizeNegative = errors.New("the page size cannot be negative")
+	InvalidPageToken = errors.New("invalid page token")
+)
+
+// Page selects a part of the users returned by List and the searches. A
+// zero Page means that all the users are returned at once in the order of
+// the index that is walked.
+//
+// The token is opaque to the clients. It contains the position of the
+// last user of the previous page in the index that is being walked
+// (email, age or creation time), which means that the pages stay stable
+// when users are created or deleted in between two calls.
+//
+// When OrderBy is given, the users are sorted in memory before being cut
+// into pages; the token then contains the values of the sort keys of the
+// last user of the previous page. The same OrderBy must be given for
+// every page.
+type Page struct {
+	Size    int32
+	Token   string
+	OrderBy []SortKey
+}
+
+// The content of a page token.
+type cursor struct {
+	Age       int32   `json:"age,omitempty"`
+	Email     string  `json:"email"`
+	FirstName string  `json:"first,omitempty"`
+	LastName  string  `json:"last,omitempty"`
+	Score     float64 `json:"score,omitempty"`   // Only used by fuzzy searches.
+	Created   int64   `json:"created,omitempty"` // In nanoseconds since 1970.
+}
+
+func newCursor(u User, sorted bool) cursor {
+	c := cursor{Age: u.Age, Email: u.Email}
+	if !u.CreatedAt.IsZero() {
+		c.Created = u.CreatedAt.UnixNano()
+	}
+	if sorted {
+		c.FirstName, c.LastName = u.FirstName, u.LastName
+	}
+	return c
+}
+
+// user returns the part of the user that the cursor knows about.
+func (c cursor) user() *User {
+	return &User{Age: c.Age, Email: c.Email, FirstName: c.FirstName, LastName: c.LastName}
+}
+
+func encodeCursor(c cursor) string {
+	bytes, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// The empty token decodes to the zero cursor, which is the start of any
+// index.
+func decodeCursor(token string) (cursor, error) {
+	if token == "" {
+		return cursor{}, nil
+	}
+
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, InvalidPageToken
+	}
+
+	var c cursor
+	err = json.Unmarshal(bytes, &c)
+	if err != nil || c.Email == "" {
+		return cursor{}, InvalidPageToken
+	}
+
+	return c, nil
+}
+
+// pager accumulates the users of a single page while an index is being
+// walked from the start position onwards.
+type pager struct {
+	page   Page
+	cursor cursor
+	users  []User
+	full   bool
+
+	// Where the index walk must start. It is the cursor, except when
+	// the users are sorted in which case the whole index is walked.
+	start cursor
+
+	// Only used when the users are sorted.
+	sorted bool
+	all    []User
+}
+
+func newPager(page Page) (*pager, error) {
+	if page.Size < 0 {
+		return nil, PageSizeNegative
+	}
+
+	err := validateOrderBy(page.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := decodeCursor(page.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pager{page: page, cursor: c, sorted: len(page.OrderBy) > 0}
+	if !p.sorted {
+		p.start = c
+	}
+
+	return p, nil
+}
+
+// add appends the user to the page. It returns false when the page is
+// full, in which case the caller must stop walking the index.
+func (p *pager) add(u *User) bool {
+	// We can only know which users belong to the page once they are
+	// all sorted.
+	if p.sorted {
+		p.all = append(p.all, *u)
+		return true
+	}
+
+	// The user the cursor points to was the last one of the previous
+	// page. Since emails are unique, comparing them is enough.
+	if p.page.Token != "" && u.Email == p.cursor.Email {
+		return true
+	}
+
+	if p.page.Size > 0 && len(p.users) == int(p.page.Size) {
+		p.full = true
+		return false
+	}
+
+	p.users = append(p.users, *u)
+	return true
+}
+
+// finish returns the users of the page and the token of the next page,
+// which is empty when this page was the last one.
+func (p *pager) finish() ([]User, string) {
+	if p.sorted {
+		p.cut()
+	}
+
+	if !p.full {
+		return p.users, ""
+	}
+	return p.users, encodeCursor(newCursor(p.users[len(p.users)-1], p.sorted))
+}
+
+// cut sorts all the users and keeps the ones that come right after the
+// cursor.
+func (p *pager) cut() {
+	sortUsers(p.all, p.page.OrderBy)
+
+	i := 0
+	if p.page.Token != "" {
+		cmp := newComparator(p.page.OrderBy)
+		last := p.cursor.user()
+		i = sort.Search(len(p.all), func(i int) bool {
+			return cmp(&p.all[i], last) > 0
+		})
+	}
+
+	for _, u := range p.all[i:] {
+		if p.page.Size > 0 && len(p.users) == int(p.page.Size) {
+			p.full = true
+			break
+		}
+		p.users = append(p.users, u)
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestPage(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+		{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+	}
+
+	t.Run("List should go through all the users page by page", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, next, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Size: 2})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, users[:2])
+		td.CmpNot(t, next, "")
+
+		got, next, err = UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, users[2:])
+		td.Cmp(t, next, "")
+	})
+
+	t.Run("List pages should not shift when users are created or deleted in between", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, next, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Size: 2})
+		td.CmpNoError(t, err)
+
+		// A user is added before the cursor and the last user of the
+		// previous page is removed.
+		td.CmpNoError(t, UserSvc{}.Create(txn, User{ID: "0a1b2c3", Email: "aaa@pod.ru"}))
+		_, err = UserSvc{}.Delete(txn, "le@rec.gb", "", "")
+		td.CmpNoError(t, err)
+
+		got, next, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, users[2:])
+		td.Cmp(t, next, "")
+	})
+
+	t.Run("SearchAge should go through all the users page by page", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, next, err := UserSvc{}.SearchAge(txn, 20, 50, Page{Size: 1})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[0]})
+
+		got, next, err = UserSvc{}.SearchAge(txn, 20, 50, Page{Size: 1, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[2]})
+
+		got, next, err = UserSvc{}.SearchAge(txn, 20, 50, Page{Size: 1, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[1]})
+		td.Cmp(t, next, "")
+	})
+
+	t.Run("SearchName should go through all the users page by page", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, next, err := UserSvc{}.SearchName(txn, "l", Page{Size: 2})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, users[:2])
+
+		got, next, err = UserSvc{}.SearchName(txn, "l", Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, users[2:])
+		td.Cmp(t, next, "")
+	})
+
+	t.Run("should return an error when the page size is negative", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		_, _, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Size: -1})
+		td.Cmp(t, err, PageSizeNegative)
+	})
+
+	t.Run("should return an error when the page token is invalid", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		_, _, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{Token: "not a token"})
+		td.Cmp(t, err, InvalidPageToken)
+	})
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"golang.org/x/crypto/argon2"
+)
+
+var (
+	PasswordTooShort   = errors.New("the password is too short")
+	InvalidCredentials = errors.New("invalid email or password")
+	AccountLocked      = errors.New("the account is locked after too many failed attempts")
+)
+
+// MinPasswordLength is the minimum number of characters of a password.
+const MinPasswordLength = 8
+
+// Credential is the password of a user. It is stored in its own table so
+// that the hash never ends up in a User, and so that a failed attempt does
+// not change the version of the user.
+type Credential struct {
+	UserID string
+
+	// The argon2id hash and its parameters, see PasswordHasher.Hash.
+	Hash string
+
+	// Number of failed attempts since the last successful one or since
+	// the last lockout, see Lockout.
+	FailedAttempts int
+	LockedUntil    time.Time
+}
+
+// Locked tells whether the account cannot be authenticated at the given
+// time.
+func (c Credential) Locked(at time.Time) bool {
+	return c.LockedUntil.After(at)
+}
+
+// PasswordHasher hashes the passwords with argon2id. The parameters are
+// stored in the hashes, which means that hashes created with other
+// parameters can still be verified after the parameters change.
+type PasswordHasher struct {
+	Time    uint32 // Number of passes over the memory.
+	Memory  uint32 // In KiB.
+	Threads uint8
+}
+
+// DefaultPasswordHasher follows the second recommended option of RFC
+// 9106: 64 MiB of memory and 3 passes.
+var DefaultPasswordHasher = PasswordHasher{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+const (
+	passwordSaltLen = 16
+	passwordKeyLen  = 32
+)
+
+// Hash returns the hash of the password in the PHC string format, e.g.
+// "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>". The salt is random.
+//
+// Possible errors: PasswordTooShort.
+func (h PasswordHasher) Hash(password string) (string, error) {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return "", fmt.Errorf("%w: it must be at least %d characters long", PasswordTooShort, MinPasswordLength)
+	}
+
+	salt := make([]byte, passwordSaltLen)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("generating a salt: %w", err)
+	}
+
+	return h.hash(password, salt), nil
+}
+
+func (h PasswordHasher) hash(password string, salt []byte) string {
+	key := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, passwordKeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+// VerifyPassword tells whether the password matches the hash created with
+// PasswordHasher.Hash. The comparison takes the same time whatever the
+// password. It returns false when the hash is malformed.
+func VerifyPassword(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" || parts[2] != fmt.Sprintf("v=%d", argon2.Version) {
+		return false
+	}
+	var h PasswordHasher
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.Memory, &h.Time, &h.Threads)
+	if err != nil {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false
+	}
+
+	got := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(got, key) == 1
+}
+
+// DummyHash returns a hash of a random password made with the same
+// parameters. Verifying a password against it takes as long as against a
+// real hash, which is used when the email is unknown so that the response
+// time does not tell which emails exist.
+func (h PasswordHasher) DummyHash() string {
+	salt := make([]byte, passwordSaltLen)
+	_, _ = rand.Read(salt)
+	return h.hash(base64.RawStdEncoding.EncodeToString(salt), salt)
+}
+
+// Lockout tells after how many consecutive failed attempts an account is
+// locked, and for how long. When MaxAttempts is 0, the accounts are never
+// locked.
+type Lockout struct {
+	MaxAttempts int
+	Duration    time.Duration
+}
+
+var DefaultLockout = Lockout{MaxAttempts: 5, Duration: 15 * time.Minute}
+
+// SetPassword sets or replaces the password of a user; the hash must be
+// made with PasswordHasher.Hash, which is kept out of this function so
+// that the hashing can be done outside of the write transaction. The
+// failed attempts and the lockout are reset. The transaction must be
+// created with write mode.
+//
+// Possible errors: EmailNotFound.
+func (UserSvc) SetPassword(txn *memdb.Txn, email, hash string) error {
+	user, err := firstActive(txn, "email", email)
+	if err != nil {
+		return fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if user == nil {
+		return EmailNotFound
+	}
+
+	err = txn.Insert("credential", &Credential{UserID: user.ID, Hash: hash})
+	if err != nil {
+		return fmt.Errorf("setting the password of %s: %w", email, err)
+	}
+
+	return nil
+}
+
+// Credential returns the user that has the given email along with its
+// credential. The credential is zero when the user has no password.
+//
+// Possible errors: EmailNotFound.
+func (UserSvc) Credential(txn *memdb.Txn, email string) (User, Credential, error) {
+	user, err := firstActive(txn, "email", email)
+	if err != nil {
+		return User{}, Credential{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if user == nil {
+		return User{}, Credential{}, EmailNotFound
+	}
+
+	raw, err := txn.First("credential", "id", user.ID)
+	if err != nil {
+		return User{}, Credential{}, fmt.Errorf("finding the credential of %s: %w", email, err)
+	}
+	if raw == nil {
+		return *user, Credential{}, nil
+	}
+
+	return *user, *raw.(*Credential), nil
+}
+
+// RecordAuthentication records whether the password given for the user
+// matched. A success resets the failed attempts; a failure counts as one
+// more failed attempt and locks the account once there are too many of
+// them. The updated credential is returned. The transaction must be
+// created with write mode.
+//
+// AccountLocked is returned when the account is locked once the attempt
+// is recorded, whether the password matched or not: while the account is
+// locked, a success changes nothing and a failure extends the lockout.
+// Since the failure is recorded, the transaction must be committed even
+// when AccountLocked is returned.
+//
+// Possible errors: AccountLocked, InvalidCredentials.
+func (svc UserSvc) RecordAuthentication(txn *memdb.Txn, userID string, succeeded bool, lockout Lockout) (Credential, error) {
+	raw, err := txn.First("credential", "id", userID)
+	if err != nil {
+		return Credential{}, fmt.Errorf("finding the credential of %s: %w", userID, err)
+	}
+	if raw == nil {
+		// The password was removed in the meantime, e.g. by Purge.
+		return Credential{}, InvalidCredentials
+	}
+	at := svc.now()
+
+	// Objects stored in memdb must never be modified in place.
+	cred := *raw.(*Credential)
+	switch {
+	case cred.Locked(at) && succeeded:
+		// The right password does not unlock the account, otherwise the
+		// lockout would not prevent guessing it.
+		return cred, AccountLocked
+	case cred.Locked(at):
+		cred.LockedUntil = at.Add(lockout.Duration)
+	case succeeded:
+		cred.FailedAttempts = 0
+		cred.LockedUntil = time.Time{}
+	case lockout.MaxAttempts > 0 && cred.FailedAttempts+1 >= lockout.MaxAttempts:
+		cred.FailedAttempts = 0
+		cred.LockedUntil = at.Add(lockout.Duration)
+	default:
+		cred.FailedAttempts++
+	}
+
+	err = txn.Insert("credential", &cred)
+	if err != nil {
+		return Credential{}, fmt.Errorf("recording the authentication of %s: %w", userID, err)
+	}
+	if cred.Locked(at) {
+		return cred, AccountLocked
+	}
+
+	return cred, nil
+}
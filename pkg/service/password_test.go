@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+// Cheap parameters so that the tests stay fast.
+var testHasher = PasswordHasher{Time: 1, Memory: 64, Threads: 1}
+
+func TestPasswordHasher(t *testing.T) {
+	hash, err := testHasher.Hash("correct horse")
+	td.CmpNoError(t, err)
+	td.Cmp(t, hash, td.HasPrefix("$argon2id$v=19$m=64,t=1,p=1$"))
+	td.CmpFalse(t, strings.Contains(hash, "correct horse"))
+
+	td.CmpTrue(t, VerifyPassword(hash, "correct horse"))
+	td.CmpFalse(t, VerifyPassword(hash, "correct horsE"))
+	td.CmpFalse(t, VerifyPassword(hash, ""))
+	td.CmpFalse(t, VerifyPassword("", "correct horse"))
+	td.CmpFalse(t, VerifyPassword("$argon2id$v=19$m=64,t=1,p=1$!!$!!", "correct horse"))
+
+	other, err := testHasher.Hash("correct horse")
+	td.CmpNoError(t, err)
+	td.Cmp(t, other, td.Not(hash), "the salt is random")
+
+	_, err = testHasher.Hash("short")
+	td.CmpTrue(t, errors.Is(err, PasswordTooShort))
+
+	td.CmpFalse(t, VerifyPassword(testHasher.DummyHash(), ""))
+}
+
+func TestPassword(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{ID: "a1", Email: "eza@pod.ru"},
+		{ID: "a2", Email: "le@rec.gb", DeletedAt: deletedAt},
+	}
+	hash, err := testHasher.Hash("correct horse")
+	td.CmpNoError(t, err)
+
+	t.Run("SetPassword should only accept active users", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, cred, err := UserSvc{}.Credential(txn, "eza@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, cred, Credential{}, "no password yet")
+
+		td.CmpNoError(t, UserSvc{}.SetPassword(txn, "eza@pod.ru", hash))
+		user, cred, err := UserSvc{}.Credential(txn, "eza@pod.ru")
+		td.CmpNoError(t, err)
+		td.Cmp(t, user, users[0])
+		td.Cmp(t, cred, Credential{UserID: "a1", Hash: hash})
+
+		td.Cmp(t, UserSvc{}.SetPassword(txn, "le@rec.gb", hash), EmailNotFound)
+		td.Cmp(t, UserSvc{}.SetPassword(txn, "nobody@rec.gb", hash), EmailNotFound)
+		_, _, err = UserSvc{}.Credential(txn, "le@rec.gb")
+		td.Cmp(t, err, EmailNotFound)
+	})
+
+	t.Run("RecordAuthentication should lock after too many failures", func(t *testing.T) {
+		clock := writtenAt
+		svc := UserSvc{Clock: func() time.Time { return clock }}
+
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+		td.CmpNoError(t, svc.SetPassword(txn, "eza@pod.ru", hash))
+
+		lockout := Lockout{MaxAttempts: 3, Duration: time.Minute}
+		cred, err := svc.RecordAuthentication(txn, "a1", false, lockout)
+		td.CmpNoError(t, err)
+		td.Cmp(t, cred.FailedAttempts, 1)
+		cred, err = svc.RecordAuthentication(txn, "a1", true, lockout)
+		td.CmpNoError(t, err)
+		td.Cmp(t, cred.FailedAttempts, 0, "a success resets the failures")
+
+		for i := 0; i < 2; i++ {
+			cred, err = svc.RecordAuthentication(txn, "a1", false, lockout)
+			td.CmpNoError(t, err)
+		}
+		cred, err = svc.RecordAuthentication(txn, "a1", false, lockout)
+		td.Cmp(t, err, AccountLocked)
+		td.Cmp(t, cred, Credential{UserID: "a1", Hash: hash, LockedUntil: writtenAt.Add(time.Minute)})
+		td.CmpTrue(t, cred.Locked(clock))
+
+		cred, err = svc.RecordAuthentication(txn, "a1", true, lockout)
+		td.Cmp(t, err, AccountLocked)
+		td.Cmp(t, cred.LockedUntil, writtenAt.Add(time.Minute), "the right password does not unlock")
+
+		clock = writtenAt.Add(30 * time.Second)
+		cred, err = svc.RecordAuthentication(txn, "a1", false, lockout)
+		td.Cmp(t, err, AccountLocked)
+		td.Cmp(t, cred.LockedUntil, clock.Add(time.Minute), "a failure extends the lockout")
+
+		clock = clock.Add(time.Minute)
+		cred, err = svc.RecordAuthentication(txn, "a1", true, lockout)
+		td.CmpNoError(t, err)
+		td.Cmp(t, cred, Credential{UserID: "a1", Hash: hash})
+
+		td.CmpNoError(t, svc.SetPassword(txn, "eza@pod.ru", hash))
+		for i := 0; i < 10; i++ {
+			cred, err = svc.RecordAuthentication(txn, "a1", false, Lockout{})
+			td.CmpNoError(t, err)
+		}
+		td.CmpFalse(t, cred.Locked(clock), "a zero lockout never locks")
+	})
+
+	t.Run("Purge should remove the password", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+		td.CmpNoError(t, UserSvc{}.SetPassword(txn, "eza@pod.ru", hash))
+
+		_, err := UserSvc{}.Delete(txn, "eza@pod.ru", "", "")
+		td.CmpNoError(t, err)
+		_, err = UserSvc{}.Purge(txn, "eza@pod.ru", "")
+		td.CmpNoError(t, err)
+
+		raw, err := txn.First("credential", "id", "a1")
+		td.CmpNoError(t, err)
+		td.CmpNil(t, raw)
+		_, err = UserSvc{}.RecordAuthentication(txn, "a1", true, DefaultLockout)
+		td.Cmp(t, err, InvalidCredentials)
+	})
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/nyaruka/phonenumbers"
+)
+
+var (
+	PhoneInvalid       = errors.New("invalid phone number")
+	PhoneNotFound      = errors.New("phone not found")
+	PhoneAlreadyExists = errors.New("phone already exists")
+)
+
+// DefaultPhoneRegion is the region assumed for the phone numbers that do
+// not start with a '+' followed by a country calling code, e.g.
+// "(906) 568-2594".
+const DefaultPhoneRegion = "US"
+
+// normalizePhone turns a phone number such as "+1 (906) 568-2594" into
+// its E.164 form, "+19065682594". The empty phone stays empty.
+//
+// Possible errors: PhoneInvalid.
+func normalizePhone(phone string) (string, error) {
+	if phone == "" {
+		return "", nil
+	}
+
+	num, err := phonenumbers.Parse(phone, DefaultPhoneRegion)
+	if err != nil || !phonenumbers.IsPossibleNumber(num) {
+		return "", PhoneInvalid
+	}
+
+	return phonenumbers.Format(num, phonenumbers.E164), nil
+}
+
+// setPhone normalizes the phone of the user and makes sure that no other
+// user has the same phone number.
+//
+// Possible errors: PhoneInvalid, PhoneAlreadyExists.
+func setPhone(txn *memdb.Txn, user *User) error {
+	e164, err := normalizePhone(user.Phone)
+	if err != nil {
+		return err
+	}
+	user.PhoneE164 = e164
+	if e164 == "" {
+		return nil
+	}
+
+	raw, err := txn.First("user", "phone", e164)
+	if err != nil {
+		return fmt.Errorf("finding if the phone %s is already used: %w", e164, err)
+	}
+	if raw != nil && raw.(*User).ID != user.ID {
+		return PhoneAlreadyExists
+	}
+
+	return nil
+}
+
+// GetByPhone returns the user that has the given phone number. The phone
+// number can be formatted in any way, e.g. "906-568-2594" finds the user
+// whose phone is "+1 (906) 568-2594"; see DefaultPhoneRegion.
+//
+// The soft-deleted users are not returned.
+//
+// Possible errors: PhoneInvalid, PhoneNotFound.
+func (UserSvc) GetByPhone(txn *memdb.Txn, phone string) (User, error) {
+	e164, err := normalizePhone(phone)
+	if err != nil {
+		return User{}, err
+	}
+	if e164 == "" {
+		return User{}, PhoneInvalid
+	}
+
+	user, err := firstActive(txn, "phone", e164)
+	if err != nil {
+		return User{}, fmt.Errorf("finding the user with phone %s: %w", e164, err)
+	}
+	if user == nil {
+		return User{}, PhoneNotFound
+	}
+
+	return *user, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func Test_normalizePhone(t *testing.T) {
+	tests := []struct {
+		given   string
+		want    string
+		wantErr error
+	}{
+		{given: "+1 (906) 568-2594", want: "+19065682594"},
+		{given: "906-568-2594", want: "+19065682594"},
+		{given: "(906) 568.2594", want: "+19065682594"},
+		{given: "+33 6 12 34 56 78", want: "+33612345678"},
+		{given: "", want: ""},
+		{given: "12", wantErr: PhoneInvalid},
+		{given: "not a phone", wantErr: PhoneInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			got, gotErr := normalizePhone(tt.given)
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetByPhone(t *testing.T) {
+	db := NewDBOrPanic()
+
+	tests := []struct {
+		name       string
+		init       func(txn *memdb.Txn)
+		givenPhone string
+		want       User
+		wantErr    error
+	}{
+		{
+			name: "should find the user whatever the formatting of the phone",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+			}),
+			givenPhone: "906 568 2594",
+			want:       User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594"},
+		},
+		{
+			name: "should return an error when no user has this phone",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594"},
+			}),
+			givenPhone: "+1 (814) 482-3880",
+			wantErr:    PhoneNotFound,
+		},
+		{
+			name:       "should return an error when the phone is empty",
+			init:       fillDBWith(nil),
+			givenPhone: "",
+			wantErr:    PhoneInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+
+			tt.init(txn)
+
+			got, gotErr := UserSvc{}.GetByPhone(txn, tt.givenPhone)
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/antzucaro/matchr"
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+// SearchNamePhonetic searches the users whose first or last name sound
+// like the query, e.g. "Chelton" finds "Shelton". The Double Metaphone
+// codes of each word of the names are computed when the users are written
+// (see phoneticIndex). When the query has several words, each of them
+// must sound like one of the words of the names. The users are sorted by
+// email. See List for how page works.
+//
+// Possible errors: NameQueryEmpty, PageSizeNegative, InvalidPageToken,
+// OrderByInvalid.
+func (UserSvc) SearchNamePhonetic(txn *memdb.Txn, query string, page Page) ([]User, string, error) {
+	if query == "" {
+		return nil, "", NameQueryEmpty
+	}
+
+	p, err := newPager(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = walkPhonetic(txn, query, p.start.Email, filter(notDeleted, p.add))
+	if err != nil {
+		return nil, "", fmt.Errorf("phonetic searching users: %w", err)
+	}
+
+	users, next := p.finish()
+	return users, next, nil
+}
+
+// phoneticIndex is a memdb indexer that indexes a user under the primary
+// and secondary Double Metaphone codes of each word of its first and last
+// names. For example, "Shelton" is indexed under "XLTN".
+type phoneticIndex struct{}
+
+func (phoneticIndex) FromObject(raw interface{}) (bool, [][]byte, error) {
+	u, ok := raw.(*User)
+	if !ok {
+		return false, nil, fmt.Errorf("phonetic index: expected a *User, got %T", raw)
+	}
+
+	set := make(map[string]struct{})
+	for _, name := range []string{u.FirstName, u.LastName} {
+		for _, code := range phoneticCodes(fold(name)) {
+			set[code] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return false, nil, nil
+	}
+
+	vals := make([][]byte, 0, len(set))
+	for code := range set {
+		vals = append(vals, []byte(code+"\x00"))
+	}
+	sort.Slice(vals, func(i, j int) bool { return string(vals[i]) < string(vals[j]) })
+
+	return true, vals, nil
+}
+
+func (phoneticIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("phonetic index: must provide only a single argument")
+	}
+	code, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("phonetic index: argument must be a string: %#v", args[0])
+	}
+
+	return []byte(code + "\x00"), nil
+}
+
+// phoneticCodes returns the Double Metaphone codes of each word of the
+// folded name; both the primary and the secondary codes are returned
+// since "Smith" is "SM0" or "XMT" depending on how it is pronounced.
+func phoneticCodes(name string) []string {
+	var codes []string
+	for _, word := range nameWords(name) {
+		primary, secondary := matchr.DoubleMetaphone(word)
+		if primary != "" {
+			codes = append(codes, primary)
+		}
+		if secondary != "" && secondary != primary {
+			codes = append(codes, secondary)
+		}
+	}
+	return codes
+}
+
+// nameWords splits a name such as "Mary-Ann O'Neil" into its words, i.e.
+// "Mary", "Ann" and "ONeil".
+func nameWords(name string) []string {
+	name = strings.NewReplacer("'", "", "’", "").Replace(name)
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// walkPhonetic goes through the users that sound like the query, sorted
+// by email, starting at the given email.
+func walkPhonetic(txn *memdb.Txn, query, fromEmail string, fn func(*User) bool) error {
+	var candidates map[string]*User
+	for _, word := range nameWords(fold(query)) {
+		// The users that sound like this word of the query.
+		found := make(map[string]*User)
+		for _, code := range phoneticCodes(word) {
+			it, err := txn.Get("user", "phonetic", code)
+			if err != nil {
+				return err
+			}
+			for raw := it.Next(); raw != nil; raw = it.Next() {
+				u := raw.(*User)
+				if candidates == nil || candidates[u.ID] != nil {
+					found[u.ID] = u
+				}
+			}
+		}
+
+		candidates = found
+		if len(candidates) == 0 {
+			return nil
+		}
+	}
+
+	users := make([]*User, 0, len(candidates))
+	for _, u := range candidates {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return strings.ToLower(users[i].Email) < strings.ToLower(users[j].Email) })
+
+	for _, u := range users {
+		if strings.ToLower(u.Email) < strings.ToLower(fromEmail) {
+			continue
+		}
+		if !fn(u) {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func Test_phoneticCodes(t *testing.T) {
+	td.Cmp(t, phoneticCodes("shelton"), []string{"XLTN"})
+	td.Cmp(t, phoneticCodes("chelton"), []string{"XLTN"})
+	td.Cmp(t, phoneticCodes("smith"), []string{"SM0", "XMT"})
+	td.Cmp(t, phoneticCodes("mary-ann o'neil"), []string{"MR", "AN", "ANL"})
+	td.Cmp(t, phoneticCodes(""), td.Nil())
+}
+
+func TestSearchNamePhonetic(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Brianna", LastName: "Shelton", Age: 52, ID: "5cfdf218", Email: "brianna.shelton@email.org"},
+		{FirstName: "Rice", LastName: "Pierce", Age: 46, ID: "5cfdf218a", Email: "rice.pierce@email.com"},
+		{FirstName: "Maël", LastName: "Smith", Age: 30, ID: "5cfdf218b", Email: "mael@email.fr"},
+		{FirstName: "Bryana", LastName: "Pearce", Age: 21, ID: "5cfdf218c", Email: "bryana@email.us"},
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    []User
+		wantErr error
+	}{
+		{name: "should find names that sound the same", query: "Chelton", want: []User{users[0]}},
+		{name: "should match the first and the last names", query: "Pearce", want: []User{users[3], users[1]}},
+		{name: "should ignore diacritics", query: "Mael", want: []User{users[2]}},
+		{name: "should use the secondary code", query: "Schmidt", want: []User{users[2]}},
+		{name: "should require every word of the query to match", query: "Brianna Pierce", want: []User{users[3]}},
+		{name: "should return nothing when no name sounds the same", query: "Keller", want: nil},
+		{name: "should return an error when the query is empty", query: "", wantErr: NameQueryEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+			fillDBWith(users)(txn)
+
+			got, _, gotErr := UserSvc{}.SearchNamePhonetic(txn, tt.query, Page{})
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("should be kept up to date when users are updated", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, err := UserSvc{}.Update(txn, "rice.pierce@email.com", []string{"name.last"}, User{LastName: "Keller"}, "")
+		td.CmpNoError(t, err)
+
+		got, _, err := UserSvc{}.SearchNamePhonetic(txn, "Pierce", Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[3]})
+	})
+}
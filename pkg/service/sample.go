@@ -12,8 +12,10 @@ import (
 
 // LoadSampleUsers loads some hard-coded users into database. The
 // transaction must be created in write mode and must be committed
-// afterwards.
-func LoadSampleUsers(txn *memdb.Txn) error {
+// afterwards. The free-text addresses of the samples are parsed into
+// structured addresses, see userfields.ParseAddress. The samples are
+// created at the time they are loaded, as given by the clock of svc.
+func (svc UserSvc) LoadSampleUsers(txn *memdb.Txn) error {
 	var users []User
 	err := json.Unmarshal(sampleUsers, &users)
 	if err != nil {
@@ -22,6 +24,12 @@ func LoadSampleUsers(txn *memdb.Txn) error {
 
 	for _, user := range users {
 		u := user
+		u.Version = 1
+		u.CreatedAt = svc.now()
+		u.UpdatedAt = u.CreatedAt
+		if err := setPhone(txn, &u); err != nil {
+			return fmt.Errorf("sample user %s: %w", u.Email, err)
+		}
 		if err := txn.Insert("user", &u); err != nil {
 			return err
 		}
@@ -13,6 +13,6 @@ func TestLoadSampleUsers(t *testing.T) {
 	// Just a quick wiring test.
 	db := service.NewDBOrPanic()
 	txn := db.Txn(true)
-	assert.NoError(t, service.LoadSampleUsers(txn))
+	assert.NoError(t, service.UserSvc{}.LoadSampleUsers(txn))
 	txn.Commit()
 }
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+// SearchQuery holds the criteria of Search. All the criteria are optional
+// and the users must match all the given criteria. The zero SearchQuery
+// matches every user.
+type SearchQuery struct {
+	// Substring of the first or last name, as in SearchName.
+	Name string
+
+	// Open-ended age range: when only one bound is given, the range is
+	// unbounded on the other side. Both bounds are included.
+	AgeFrom *int32
+	AgeTo   *int32
+
+	// The part after the '@', e.g. "email.org". Subdomains do not match.
+	EmailDomain string
+
+	// Only the digits are compared, which means that "906-568" matches
+	// "+1 (906) 568-2594". When given, it must contain at least one digit.
+	Phone string
+
+	// Substring of the whole address; case and diacritics are ignored.
+	Address string
+
+	// The city and the region of the address must be equal to these ones,
+	// ignoring the case and the diacritics.
+	City   string
+	Region string
+
+	// The users must have been created in this range, see TimeRange.
+	Created TimeRange
+
+	// The labels of the users must match the selector.
+	Selector Selector
+
+	// The soft-deleted users are hidden unless this is true.
+	IncludeDeleted bool
+}
+
+// Search returns the users that match all the criteria of the query.
+// When an age bound is given, the age index is used for the range part,
+// which means the users are sorted by age and then by email. Otherwise,
+// when a creation range is given, the created index is used and the users
+// are sorted by creation time and then by email. Otherwise, they are
+// sorted by email and the city, region, label or trigram index is used, in
+// this order of preference. See List for how page works.
+//
+// Possible errors: AgeFromIsGreaterThanAgeTo, PhoneQueryInvalid,
+// TimeRangeInvalid, PageSizeNegative, InvalidPageToken, OrderByInvalid.
+func (UserSvc) Search(txn *memdb.Txn, query SearchQuery, page Page) ([]User, string, error) {
+	if query.AgeFrom != nil && query.AgeTo != nil && *query.AgeFrom > *query.AgeTo {
+		return nil, "", AgeFromIsGreaterThanAgeTo
+	}
+	if query.Phone != "" && digits(query.Phone) == "" {
+		return nil, "", PhoneQueryInvalid
+	}
+	err := query.Created.validate()
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, err := newPager(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fn := filter(query.matcher(), p.add)
+
+	if query.AgeFrom == nil && query.AgeTo == nil {
+		switch {
+		case !query.Created.IsZero():
+			err = walkCreatedRange(txn, query.Created, p.start, fn)
+		case query.City != "":
+			err = walkAddress(txn, cityIndex, query.City, p.start.Email, fn)
+		case query.Region != "":
+			err = walkAddress(txn, regionIndex, query.Region, p.start.Email, fn)
+		case len(query.Selector) > 0:
+			err = walkLabels(txn, query.Selector, p.start.Email, fn)
+		case query.Name != "":
+			// The trigram index narrows down the users to go through.
+			err = walkName(txn, query.Name, p.start.Email, fn)
+		default:
+			err = walkEmail(txn, p.start.Email, fn)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("searching users: %w", err)
+		}
+
+		users, next := p.finish()
+		return users, next, nil
+	}
+
+	ageFrom, ageTo := int32(math.MinInt32), int32(math.MaxInt32)
+	if query.AgeFrom != nil {
+		ageFrom = *query.AgeFrom
+	}
+	if query.AgeTo != nil {
+		ageTo = *query.AgeTo
+	}
+
+	// When we are resuming from a previous page, we start where the
+	// previous page stopped.
+	startAge, startEmail := ageFrom, ""
+	if p.start.Email != "" && p.start.Age >= ageFrom {
+		startAge, startEmail = p.start.Age, p.start.Email
+	}
+
+	err = walkAge(txn, startAge, startEmail, ageTo, fn)
+	if err != nil {
+		return nil, "", fmt.Errorf("searching users starting at age %d: %w", startAge, err)
+	}
+
+	users, next := p.finish()
+	return users, next, nil
+}
+
+// matcher returns a function that tells whether a user matches all the
+// criteria of the query except the age range, which is taken care of by
+// the age index.
+func (query SearchQuery) matcher() func(*User) bool {
+	matchers := []func(*User) bool{visible(query.IncludeDeleted)}
+
+	if query.Name != "" {
+		matchers = append(matchers, nameMatcher(query.Name))
+	}
+
+	if query.EmailDomain != "" {
+		domain := "@" + strings.ToLower(strings.TrimPrefix(query.EmailDomain, "@"))
+		matchers = append(matchers, func(u *User) bool {
+			return strings.HasSuffix(strings.ToLower(u.Email), domain)
+		})
+	}
+
+	if query.Phone != "" {
+		phone := digits(query.Phone)
+		matchers = append(matchers, func(u *User) bool {
+			return strings.Contains(digits(u.Phone), phone)
+		})
+	}
+
+	if query.Address != "" {
+		address := fold(query.Address)
+		matchers = append(matchers, func(u *User) bool {
+			return strings.Contains(fold(u.Address.String()), address)
+		})
+	}
+
+	if query.City != "" {
+		city := fold(query.City)
+		matchers = append(matchers, func(u *User) bool {
+			return fold(u.Address.City) == city
+		})
+	}
+
+	if query.Region != "" {
+		region := fold(query.Region)
+		matchers = append(matchers, func(u *User) bool {
+			return fold(u.Address.Region) == region
+		})
+	}
+
+	if !query.Created.IsZero() {
+		matchers = append(matchers, createdMatcher(query.Created))
+	}
+
+	if len(query.Selector) > 0 {
+		matchers = append(matchers, query.Selector.matcher())
+	}
+
+	return func(u *User) bool {
+		for _, match := range matchers {
+			if !match(u) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// digits only keeps the digits of the given string.
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsDigit(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestSearch(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", Address: Address{Street: "291 Boardwalk", City: "Chloride", Region: "North Carolina", PostalCode: "8401"}},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb", Phone: "+1 (814) 482-3880", Address: Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}},
+		{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Phone: "+1 (906) 429-3517", Address: Address{Street: "948 Jefferson Street", City: "Guthrie", Region: "Louisiana", PostalCode: "2483"}},
+		{FirstName: "Léonie", LastName: "Noël", Age: 38, ID: "f1e2d3c", Email: "leonie@Pod.ru", Phone: "+33 6 12 34 56 78", Address: Address{Street: "12 rue de l'Église", City: "Besançon", PostalCode: "25000", Country: "France"}},
+	}
+	age := func(age int32) *int32 { return &age }
+
+	tests := []struct {
+		name    string
+		query   SearchQuery
+		want    []User
+		wantErr error
+	}{
+		{
+			name:  "should return all the users when no criteria is given",
+			query: SearchQuery{},
+			want:  []User{users[0], users[1], users[3], users[2]},
+		},
+		{
+			name:  "should combine the name and the age range",
+			query: SearchQuery{Name: "l", AgeFrom: age(30), AgeTo: age(40)},
+			want:  []User{users[3], users[2]},
+		},
+		{
+			name:  "should return the users above the age when only AgeFrom is given",
+			query: SearchQuery{AgeFrom: age(38)},
+			want:  []User{users[3], users[2], users[1]},
+		},
+		{
+			name:  "should return the users below the age when only AgeTo is given",
+			query: SearchQuery{AgeTo: age(38)},
+			want:  []User{users[0], users[3], users[2]},
+		},
+		{
+			name:  "should match the email domain regardless of the case",
+			query: SearchQuery{EmailDomain: "pod.ru"},
+			want:  []User{users[0], users[3]},
+		},
+		{
+			name:  "should not match a part of the email domain",
+			query: SearchQuery{EmailDomain: "od.ru"},
+			want:  nil,
+		},
+		{
+			name:  "should only compare the digits of the phone",
+			query: SearchQuery{Phone: "906-568"},
+			want:  []User{users[0]},
+		},
+		{
+			name:  "should ignore the case and the diacritics of the address",
+			query: SearchQuery{Address: "EGLISE"},
+			want:  []User{users[3]},
+		},
+		{
+			name:  "should find the users of a city regardless of the case and the diacritics",
+			query: SearchQuery{City: "besancon"},
+			want:  []User{users[3]},
+		},
+		{
+			name:  "should find the users of a region regardless of the case",
+			query: SearchQuery{Region: "INDIANA"},
+			want:  []User{users[1]},
+		},
+		{
+			name:  "should not match a part of the region",
+			query: SearchQuery{Region: "Carolina"},
+			want:  nil,
+		},
+		{
+			name:  "should combine the region and the age range",
+			query: SearchQuery{Region: "Louisiana", AgeFrom: age(30)},
+			want:  []User{users[2]},
+		},
+		{
+			name:  "should combine the city and the region",
+			query: SearchQuery{City: "Volta", Region: "Louisiana"},
+			want:  nil,
+		},
+		{
+			name:  "should AND all the criteria",
+			query: SearchQuery{Phone: "906", AgeTo: age(30)},
+			want:  []User{users[0]},
+		},
+		{
+			name:    "should return an error when AgeFrom is above AgeTo",
+			query:   SearchQuery{AgeFrom: age(40), AgeTo: age(30)},
+			wantErr: AgeFromIsGreaterThanAgeTo,
+		},
+		{
+			name:    "should return an error when the phone has no digits",
+			query:   SearchQuery{Phone: "abc"},
+			wantErr: PhoneQueryInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+			fillDBWith(users)(txn)
+
+			got, _, gotErr := UserSvc{}.Search(txn, tt.query, Page{})
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("should keep the ages below 0 and above 127 in order", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		aged := []User{
+			{ID: "b1", Email: "a@pod.ru", Age: -6},
+			{ID: "b2", Email: "b@pod.ru", Age: 130},
+			{ID: "b3", Email: "c@pod.ru", Age: 30},
+			{ID: "b4", Email: "d@pod.ru", Age: 100},
+			{ID: "b5", Email: "e@pod.ru", Age: 127},
+			{ID: "b6", Email: "f@pod.ru", Age: 128},
+		}
+		fillDBWith(aged)(txn)
+
+		got, _, err := UserSvc{}.Search(txn, SearchQuery{AgeFrom: age(5)}, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{aged[2], aged[3], aged[4], aged[5], aged[1]})
+
+		got, _, err = UserSvc{}.Search(txn, SearchQuery{AgeTo: age(127)}, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{aged[0], aged[2], aged[3], aged[4]})
+	})
+
+	t.Run("should go through the users of an age range page by page", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		query := SearchQuery{Name: "l", AgeFrom: age(30)}
+
+		got, next, err := UserSvc{}.Search(txn, query, Page{Size: 2})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[3], users[2]})
+
+		got, next, err = UserSvc{}.Search(txn, query, Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[1]})
+		td.Cmp(t, next, "")
+	})
+}
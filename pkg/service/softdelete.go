@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+var UserNotDeleted = errors.New("the user is not deleted")
+
+// Deleted tells whether the user was soft-deleted, see Delete.
+func (u User) Deleted() bool {
+	return !u.DeletedAt.IsZero()
+}
+
+// notDeleted is a matcher that hides the soft-deleted users; see filter.
+func notDeleted(u *User) bool {
+	return !u.Deleted()
+}
+
+// visible returns the matcher used by the reads that have an "include
+// deleted" option.
+func visible(includeDeleted bool) func(*User) bool {
+	if includeDeleted {
+		return func(*User) bool { return true }
+	}
+	return notDeleted
+}
+
+// firstActive is like txn.First except that the soft-deleted users are
+// not returned. It returns nil when no user is found.
+func firstActive(txn *memdb.Txn, index string, args ...interface{}) (*User, error) {
+	raw, err := txn.First("user", index, args...)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil || raw.(*User).Deleted() {
+		return nil, nil
+	}
+
+	return raw.(*User), nil
+}
+
+// Restore brings back a user that was soft-deleted using Delete. When etag
+// is not empty, the user must still have this etag. The manager of
+// the user is removed when it no longer exists or when it would make the
+// reporting chain loop. The transaction must be created with write mode.
+//
+// Possible errors: EmailNotFound, UserNotDeleted, EtagInvalid,
+// VersionMismatch.
+func (svc UserSvc) Restore(txn *memdb.Txn, email, etag string) (User, error) {
+	raw, err := txn.First("user", "email", email)
+	if err != nil {
+		return User{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if raw == nil {
+		return User{}, EmailNotFound
+	}
+	if !raw.(*User).Deleted() {
+		return User{}, UserNotDeleted
+	}
+	err = checkEtag(raw.(*User), etag)
+	if err != nil {
+		return User{}, err
+	}
+
+	// Objects stored in memdb must never be modified in place.
+	restored := *raw.(*User)
+	restored.DeletedAt = time.Time{}
+	err = checkManager(txn, &restored, restored.ManagerID)
+	switch {
+	case err == ManagerNotFound, err == ManagerCycle:
+		restored.ManagerID = ""
+	case err != nil:
+		return User{}, fmt.Errorf("restoring user %s: %w", email, err)
+	}
+	touch(&restored, svc.now())
+	err = txn.Insert("user", &restored)
+	if err != nil {
+		return User{}, fmt.Errorf("restoring user %s: %w", email, err)
+	}
+
+	return restored, nil
+}
+
+// Purge removes for good a user that was soft-deleted using Delete, which
+// means that its email and its phone can be used again. When etag is not
+// empty, the user must still have this etag. The transaction must be
+// created with write mode. The purged user is returned; its password, if
+// any, is removed, it is removed from its groups and its direct reports
+// now report to its manager.
+//
+// Possible errors: EmailNotFound, UserNotDeleted, EtagInvalid,
+// VersionMismatch.
+func (svc UserSvc) Purge(txn *memdb.Txn, email, etag string) (User, error) {
+	raw, err := txn.First("user", "email", email)
+	if err != nil {
+		return User{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if raw == nil {
+		return User{}, EmailNotFound
+	}
+	if !raw.(*User).Deleted() {
+		return User{}, UserNotDeleted
+	}
+	err = checkEtag(raw.(*User), etag)
+	if err != nil {
+		return User{}, err
+	}
+
+	err = txn.Delete("user", raw)
+	if err != nil {
+		return User{}, fmt.Errorf("purging user %s: %w", email, err)
+	}
+	_, err = txn.DeleteAll("credential", "id", raw.(*User).ID)
+	if err != nil {
+		return User{}, fmt.Errorf("removing the password of %s: %w", email, err)
+	}
+	err = leaveGroups(txn, raw.(*User).ID)
+	if err != nil {
+		return User{}, fmt.Errorf("purging user %s: %w", email, err)
+	}
+	err = reassignReports(txn, raw.(*User), svc.now())
+	if err != nil {
+		return User{}, fmt.Errorf("purging user %s: %w", email, err)
+	}
+
+	return *raw.(*User), nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+var deletedAt = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestSoftDelete_hidesDeletedUsers(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594", DeletedAt: deletedAt},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+	}
+
+	txn := db.Txn(true)
+	defer txn.Abort()
+	fillDBWith(users)(txn)
+
+	got, _, err := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User{users[1]})
+
+	got, _, err = UserSvc{}.List(txn, true, TimeRange{}, nil, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, users)
+
+	got, _, err = UserSvc{}.Search(txn, SearchQuery{Name: "elnora"}, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User(nil))
+
+	got, _, err = UserSvc{}.Search(txn, SearchQuery{Name: "elnora", IncludeDeleted: true}, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User{users[0]})
+
+	got, _, err = UserSvc{}.SearchName(txn, "elnora", Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User(nil))
+
+	got, _, err = UserSvc{}.SearchAge(txn, 0, 100, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User{users[1]})
+
+	_, err = UserSvc{}.GetByID(txn, "ba3d530")
+	td.Cmp(t, err, IDNotFound)
+
+	_, err = UserSvc{}.GetByPhone(txn, "906-568-2594")
+	td.Cmp(t, err, PhoneNotFound)
+
+	_, err = UserSvc{}.Update(txn, "eza@pod.ru", []string{"age"}, User{Age: 22}, "")
+	td.Cmp(t, err, EmailNotFound)
+
+	_, err = UserSvc{}.ChangeEmail(txn, "eza@pod.ru", "elnora@pod.ru", "")
+	td.Cmp(t, err, EmailNotFound)
+}
+
+func TestRestore(t *testing.T) {
+	db := NewDBOrPanic()
+	svc := UserSvc{Clock: func() time.Time { return writtenAt }}
+
+	tests := []struct {
+		name       string
+		init       func(txn *memdb.Txn)
+		givenEmail string
+		want       User
+		wantErr    error
+	}{
+		{
+			name: "should bring back a deleted user",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", DeletedAt: deletedAt},
+			}),
+			givenEmail: "eza@pod.ru",
+			want:       User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should keep a manager that is deleted",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", ManagerID: "c7dca0a", DeletedAt: deletedAt},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb", DeletedAt: deletedAt},
+			}),
+			givenEmail: "eza@pod.ru",
+			want:       User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", ManagerID: "c7dca0a", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should remove a manager that no longer exists",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", ManagerID: "c7dca0a", DeletedAt: deletedAt},
+			}),
+			givenEmail: "eza@pod.ru",
+			want:       User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should remove a manager that would make the chain loop",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", ManagerID: "c7dca0a", DeletedAt: deletedAt},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb", ManagerID: "ba3d530"},
+			}),
+			givenEmail: "eza@pod.ru",
+			want:       User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should return an error when the user is not deleted",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			givenEmail: "eza@pod.ru",
+			wantErr:    UserNotDeleted,
+		},
+		{
+			name:       "should return an error when no user has this email",
+			init:       fillDBWith(nil),
+			givenEmail: "eza@pod.ru",
+			wantErr:    EmailNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+
+			tt.init(txn)
+
+			got, gotErr := svc.Restore(txn, tt.givenEmail, "")
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+
+				inDB, err := svc.GetByEmail(txn, tt.givenEmail, false)
+				if td.CmpNoError(t, err) {
+					td.Cmp(t, inDB, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	db := NewDBOrPanic()
+
+	tests := []struct {
+		name       string
+		init       func(txn *memdb.Txn)
+		givenEmail string
+		want       User
+		wantErr    error
+	}{
+		{
+			name: "should remove a deleted user for good",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", DeletedAt: deletedAt},
+			}),
+			givenEmail: "eza@pod.ru",
+			want:       User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", DeletedAt: deletedAt},
+		},
+		{
+			name: "should return an error when the user is not deleted",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			givenEmail: "eza@pod.ru",
+			wantErr:    UserNotDeleted,
+		},
+		{
+			name:       "should return an error when no user has this email",
+			init:       fillDBWith(nil),
+			givenEmail: "eza@pod.ru",
+			wantErr:    EmailNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+
+			tt.init(txn)
+
+			got, gotErr := UserSvc{}.Purge(txn, tt.givenEmail, "")
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+
+				_, err := UserSvc{}.GetByEmail(txn, tt.givenEmail, true)
+				td.Cmp(t, err, EmailNotFound)
+			}
+		})
+	}
+}
+
+func TestPurge_freesTheEmail(t *testing.T) {
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	defer txn.Abort()
+
+	td.CmpNoError(t, UserSvc{}.Create(txn, User{ID: "ba3d530", Email: "eza@pod.ru"}))
+	_, err := UserSvc{}.Delete(txn, "eza@pod.ru", "", "")
+	td.CmpNoError(t, err)
+
+	// The email is still taken by the deleted user.
+	td.Cmp(t, UserSvc{}.Create(txn, User{ID: "c7dca0a", Email: "eza@pod.ru"}), EmailAlreadyExists)
+
+	_, err = UserSvc{}.Purge(txn, "eza@pod.ru", "")
+	td.CmpNoError(t, err)
+	td.CmpNoError(t, UserSvc{}.Create(txn, User{ID: "c7dca0a", Email: "eza@pod.ru"}))
+}
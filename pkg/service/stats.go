@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+var AgeBucketsInvalid = errors.New("the age bucket bounds must be positive and increasing")
+
+// DefaultAgeBuckets are the bounds of the age histogram used when none are
+// given; see Stats.
+var DefaultAgeBuckets = []int32{18, 25, 35, 45, 55, 65}
+
+// Stats gives an overview of the users that are not soft-deleted.
+type Stats struct {
+	Total int
+
+	// Zero when there are no users.
+	MinAge, MaxAge     int32
+	MeanAge, MedianAge float64
+
+	AgeBuckets []AgeBucket
+
+	// Sorted from the most common to the least common; the ties are sorted
+	// by key. The users without a region are not counted in Regions.
+	EmailDomains []Count
+	Regions      []Count
+}
+
+// AgeBucket counts the users whose age is in [From, To). The last bucket
+// has no upper bound, in which case To is 0.
+type AgeBucket struct {
+	From, To int32
+	Count    int
+}
+
+type Count struct {
+	Key   string
+	Count int
+}
+
+// Stats computes the statistics of the users that are not soft-deleted.
+// The ages are read in order from the age index, which means that the
+// median is found without sorting the users. The regions are grouped the
+// same way they are searched, e.g. "Indiana" and "indiana" are the same
+// region; the region shown is the one of the first user by email.
+//
+// The age histogram is made of the buckets delimited by the given bounds,
+// e.g. the bounds 18, 30 give the buckets [0, 18), [18, 30) and [30, ∞).
+// The first bucket also counts the negative ages, if any.
+//
+// Possible errors: AgeBucketsInvalid.
+func (UserSvc) Stats(txn *memdb.Txn, bounds []int32) (Stats, error) {
+	for i, b := range bounds {
+		if b <= 0 || (i > 0 && b <= bounds[i-1]) {
+			return Stats{}, AgeBucketsInvalid
+		}
+	}
+
+	stats := Stats{AgeBuckets: make([]AgeBucket, len(bounds)+1)}
+	for i := range stats.AgeBuckets {
+		if i > 0 {
+			stats.AgeBuckets[i].From = bounds[i-1]
+		}
+		if i < len(bounds) {
+			stats.AgeBuckets[i].To = bounds[i]
+		}
+	}
+
+	var ages []int32 // Sorted since they come from the age index.
+	sum := 0.0
+	domains := make(map[string]int)
+	bucket := 0
+	err := walkAge(txn, math.MinInt32, "", math.MaxInt32, filter(notDeleted, func(u *User) bool {
+		ages = append(ages, u.Age)
+		sum += float64(u.Age)
+
+		for bucket < len(bounds) && u.Age >= bounds[bucket] {
+			bucket++
+		}
+		stats.AgeBuckets[bucket].Count++
+
+		domains[emailDomain(u.Email)]++
+		return true
+	}))
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats.Total = len(ages)
+	if stats.Total > 0 {
+		stats.MinAge, stats.MaxAge = ages[0], ages[len(ages)-1]
+		stats.MeanAge = sum / float64(len(ages))
+		stats.MedianAge = float64(ages[len(ages)/2])
+		if len(ages)%2 == 0 {
+			stats.MedianAge = float64(ages[len(ages)/2-1]+ages[len(ages)/2]) / 2
+		}
+	}
+
+	for domain, count := range domains {
+		stats.EmailDomains = append(stats.EmailDomains, Count{Key: domain, Count: count})
+	}
+	sortCounts(stats.EmailDomains)
+
+	stats.Regions, err = countRegions(txn)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return stats, nil
+}
+
+// countRegions goes through the region index in which the users of the
+// same region are next to each other.
+func countRegions(txn *memdb.Txn) ([]Count, error) {
+	it, err := txn.LowerBound("user", "region", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []Count
+	folded := ""
+	for raw := it.Next(); raw != nil; raw = it.Next() {
+		u := raw.(*User)
+		if u.Deleted() {
+			continue
+		}
+		if f := fold(u.Address.Region); len(counts) == 0 || f != folded {
+			folded = f
+			counts = append(counts, Count{Key: u.Address.Region})
+		}
+		counts[len(counts)-1].Count++
+	}
+	sortCounts(counts)
+
+	return counts, nil
+}
+
+func sortCounts(counts []Count) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Key < counts[j].Key
+	})
+}
+
+// emailDomain returns the part after the '@' in lowercase, e.g.
+// "email.org" for "Brianna.Shelton@Email.org".
+func emailDomain(email string) string {
+	return strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+}
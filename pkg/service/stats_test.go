@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestStats(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{ID: "a1", Email: "a@pod.ru", Age: 30, Address: Address{Region: "Indiana"}},
+		{ID: "a2", Email: "b@Pod.ru", Age: 17, Address: Address{Region: "indiana"}},
+		{ID: "a3", Email: "c@email.org", Age: 45, Address: Address{Region: "Ohio"}},
+		{ID: "a4", Email: "d@email.org", Age: 18},
+		{ID: "a5", Email: "e@email.org", Age: 62, Address: Address{Region: "Ohio"}, DeletedAt: deletedAt},
+		{ID: "a6", Email: "f@pod.ru", Age: 50, Address: Address{Region: "Maine"}},
+	}
+
+	t.Run("should compute the statistics of the users that are not deleted", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, err := UserSvc{}.Stats(txn, []int32{18, 40})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, Stats{
+			Total:     5,
+			MinAge:    17,
+			MaxAge:    50,
+			MeanAge:   32,
+			MedianAge: 30,
+			AgeBuckets: []AgeBucket{
+				{From: 0, To: 18, Count: 1},
+				{From: 18, To: 40, Count: 2},
+				{From: 40, To: 0, Count: 2},
+			},
+			EmailDomains: []Count{{Key: "pod.ru", Count: 3}, {Key: "email.org", Count: 2}},
+			Regions:      []Count{{Key: "Indiana", Count: 2}, {Key: "Maine", Count: 1}, {Key: "Ohio", Count: 1}},
+		})
+	})
+
+	t.Run("should average the two middle ages when the count is even", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users[:4])(txn)
+
+		got, err := UserSvc{}.Stats(txn, nil)
+		td.CmpNoError(t, err)
+		td.Cmp(t, got.MedianAge, 24.0)
+		td.Cmp(t, got.AgeBuckets, []AgeBucket{{Count: 4}})
+	})
+
+	t.Run("should sort the ages below 0 and above 127", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith([]User{
+			{ID: "b1", Email: "a@pod.ru", Age: -6},
+			{ID: "b2", Email: "b@pod.ru", Age: 130},
+			{ID: "b3", Email: "c@pod.ru", Age: 30},
+			{ID: "b4", Email: "d@pod.ru", Age: 100},
+		})(txn)
+
+		got, err := UserSvc{}.Stats(txn, []int32{128})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got.MinAge, int32(-6))
+		td.Cmp(t, got.MaxAge, int32(130))
+		td.Cmp(t, got.MedianAge, 65.0)
+		td.Cmp(t, got.AgeBuckets, []AgeBucket{{From: 0, To: 128, Count: 3}, {From: 128, Count: 1}})
+	})
+
+	t.Run("should return zeros when there are no users", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		got, err := UserSvc{}.Stats(txn, DefaultAgeBuckets)
+		td.CmpNoError(t, err)
+		td.Cmp(t, got.Total, 0)
+		td.Cmp(t, got.MedianAge, 0.0)
+		td.Cmp(t, got.AgeBuckets, td.Len(len(DefaultAgeBuckets)+1))
+	})
+
+	t.Run("should return an error when the bounds are not increasing", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		_, err := UserSvc{}.Stats(txn, []int32{30, 18})
+		td.Cmp(t, err, AgeBucketsInvalid)
+		_, err = UserSvc{}.Stats(txn, []int32{0, 18})
+		td.Cmp(t, err, AgeBucketsInvalid)
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+var TimeRangeInvalid = errors.New("the start of the time range must be before its end")
+
+// touch must be called on the copy of a user that is about to be written
+// back: its version is incremented and UpdatedAt is set to the given time.
+func touch(u *User, at time.Time) {
+	u.Version++
+	u.UpdatedAt = at
+}
+
+// TimeRange is used for filtering the users on their creation time. Both
+// bounds are excluded and the zero time means that the range is unbounded
+// on that side. The users without a creation time are never in a range,
+// except in the zero TimeRange which matches every user.
+type TimeRange struct {
+	After  time.Time
+	Before time.Time
+}
+
+// IsZero tells whether the range is unbounded on both sides.
+func (r TimeRange) IsZero() bool {
+	return r.After.IsZero() && r.Before.IsZero()
+}
+
+func (r TimeRange) validate() error {
+	if !r.After.IsZero() && !r.Before.IsZero() && !r.After.Before(r.Before) {
+		return TimeRangeInvalid
+	}
+	return nil
+}
+
+func (r TimeRange) contains(t time.Time) bool {
+	switch {
+	case r.IsZero():
+		return true
+	case t.IsZero():
+		return false
+	case !r.After.IsZero() && !t.After(r.After):
+		return false
+	case !r.Before.IsZero() && !t.Before(r.Before):
+		return false
+	}
+	return true
+}
+
+// createdMatcher returns the matcher of the users created in the range.
+func createdMatcher(r TimeRange) func(*User) bool {
+	return func(u *User) bool {
+		return r.contains(u.CreatedAt)
+	}
+}
+
+// timeFieldIndex is a memdb indexer for the timestamps of the users. Users
+// whose timestamp is the zero time are not indexed.
+type timeFieldIndex struct {
+	name  string
+	field func(*User) time.Time
+}
+
+func (idx timeFieldIndex) FromObject(raw interface{}) (bool, []byte, error) {
+	u, ok := raw.(*User)
+	if !ok {
+		return false, nil, fmt.Errorf("%s index: expected a *User, got %T", idx.name, raw)
+	}
+
+	t := idx.field(u)
+	if t.IsZero() {
+		return false, nil, nil
+	}
+
+	return true, encodeTime(t), nil
+}
+
+func (idx timeFieldIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%s index: must provide only a single argument", idx.name)
+	}
+	t, ok := args[0].(time.Time)
+	if !ok {
+		return nil, fmt.Errorf("%s index: argument must be a time.Time: %#v", idx.name, args[0])
+	}
+
+	return encodeTime(t), nil
+}
+
+// encodeTime encodes the time so that the byte order is the time order:
+// the seconds since 1970 with their sign bit flipped, followed by the
+// nanoseconds, both in big endian.
+func encodeTime(t time.Time) []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, uint64(t.Unix())^(1<<63))
+	binary.BigEndian.PutUint32(b[8:], uint32(t.Nanosecond()))
+	return b
+}
+
+var (
+	createdIndex = timeFieldIndex{name: "created", field: func(u *User) time.Time { return u.CreatedAt }}
+	updatedIndex = timeFieldIndex{name: "updated", field: func(u *User) time.Time { return u.UpdatedAt }}
+)
+
+// walkCreated does a range scan over the created index, starting at the
+// user created at fromTime with the email fromEmail and stopping at the
+// users created at or after before, unless before is the zero time.
+func walkCreated(txn *memdb.Txn, fromTime time.Time, fromEmail string, before time.Time, fn func(*User) bool) error {
+	it, err := txn.LowerBound("user", "created", fromTime, fromEmail)
+	if err != nil {
+		return err
+	}
+
+	for raw := it.Next(); raw != nil; raw = it.Next() {
+		u := raw.(*User)
+		if !before.IsZero() && !u.CreatedAt.Before(before) {
+			break
+		}
+		if !fn(u) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// walkCreatedRange walks the users created in the range, sorted by
+// creation time and then by email. When start is not the zero cursor, the
+// walk resumes where the previous page stopped.
+func walkCreatedRange(txn *memdb.Txn, r TimeRange, start cursor, fn func(*User) bool) error {
+	fromTime, fromEmail := r.After, ""
+	if start.Email != "" && start.Created != 0 && !time.Unix(0, start.Created).Before(r.After) {
+		fromTime, fromEmail = time.Unix(0, start.Created), start.Email
+	}
+
+	return walkCreated(txn, fromTime, fromEmail, r.Before, filter(createdMatcher(r), fn))
+}
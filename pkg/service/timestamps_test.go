@@ -0,0 +1,151 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+var writtenAt = time.Date(2020, 6, 2, 8, 30, 0, 0, time.UTC)
+
+func Test_encodeTime(t *testing.T) {
+	times := []time.Time{
+		{},
+		time.Date(1969, 12, 31, 23, 59, 59, 999, time.UTC),
+		time.Unix(0, 0),
+		time.Unix(0, 1),
+		writtenAt,
+		writtenAt.Add(time.Nanosecond),
+		writtenAt.Add(time.Second),
+	}
+	for i := 1; i < len(times); i++ {
+		td.CmpTrue(t, bytes.Compare(encodeTime(times[i-1]), encodeTime(times[i])) < 0, "%s must sort before %s", times[i-1], times[i])
+	}
+}
+
+func TestTimeRange_contains(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2020, 6, d, 0, 0, 0, 0, time.UTC) }
+
+	td.CmpTrue(t, TimeRange{}.contains(time.Time{}))
+	td.CmpTrue(t, TimeRange{After: day(1)}.contains(day(2)))
+	td.CmpFalse(t, TimeRange{After: day(1)}.contains(day(1)))
+	td.CmpTrue(t, TimeRange{Before: day(2)}.contains(day(1)))
+	td.CmpFalse(t, TimeRange{Before: day(2)}.contains(day(2)))
+	td.CmpFalse(t, TimeRange{Before: day(2)}.contains(time.Time{}))
+	td.CmpTrue(t, TimeRange{After: day(1), Before: day(3)}.contains(day(2)))
+}
+
+func TestTimestamps(t *testing.T) {
+	clock := writtenAt
+	svc := UserSvc{Clock: func() time.Time { return clock }}
+
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	defer txn.Abort()
+
+	td.CmpNoError(t, svc.Create(txn, User{ID: "ba3d530", Email: "eza@pod.ru", CreatedAt: deletedAt}))
+	got, err := svc.GetByEmail(txn, "eza@pod.ru", false)
+	td.CmpNoError(t, err)
+	td.Cmp(t, got.CreatedAt, writtenAt) // The given one is ignored.
+	td.Cmp(t, got.UpdatedAt, writtenAt)
+
+	later := writtenAt.Add(time.Hour)
+	clock = later
+
+	got, err = svc.Update(txn, "eza@pod.ru", []string{"age"}, User{Age: 22}, "")
+	td.CmpNoError(t, err)
+	td.Cmp(t, got.CreatedAt, writtenAt)
+	td.Cmp(t, got.UpdatedAt, later)
+
+	// The updated index follows the writes.
+	raw, err := txn.First("user", "updated", later, "eza@pod.ru")
+	td.CmpNoError(t, err)
+	td.Cmp(t, raw, td.Smuggle("Email", "eza@pod.ru"))
+	raw, err = txn.First("user", "updated", writtenAt, "eza@pod.ru")
+	td.CmpNoError(t, err)
+	td.Cmp(t, raw, nil)
+}
+
+func TestList_created(t *testing.T) {
+	db := NewDBOrPanic()
+	day := func(d int) time.Time { return time.Date(2020, 6, d, 0, 0, 0, 0, time.UTC) }
+	users := []User{
+		{ID: "a1", Email: "a@pod.ru", CreatedAt: day(4)},
+		{ID: "a2", Email: "b@pod.ru", CreatedAt: day(2)},
+		{ID: "a3", Email: "c@pod.ru", CreatedAt: day(3)},
+		{ID: "a4", Email: "d@pod.ru", CreatedAt: day(2)},
+		{ID: "a5", Email: "e@pod.ru", CreatedAt: day(1)},
+		{ID: "a6", Email: "f@pod.ru"}, // Created before the timestamps existed.
+		{ID: "a7", Email: "g@pod.ru", CreatedAt: day(3), DeletedAt: deletedAt},
+	}
+
+	t.Run("should only list the users created in the range, sorted by creation time", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, _, err := UserSvc{}.List(txn, false, TimeRange{After: day(1), Before: day(4)}, nil, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[1], users[3], users[2]})
+
+		got, _, err = UserSvc{}.List(txn, false, TimeRange{Before: day(2)}, nil, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[4]})
+
+		got, _, err = UserSvc{}.List(txn, true, TimeRange{After: day(2)}, nil, Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[2], users[6], users[0]})
+	})
+
+	t.Run("should go through the pages in order", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, next, err := UserSvc{}.List(txn, false, TimeRange{After: day(1)}, nil, Page{Size: 2})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[1], users[3]})
+
+		got, next, err = UserSvc{}.List(txn, false, TimeRange{After: day(1)}, nil, Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[2], users[0]})
+		td.Cmp(t, next, "")
+	})
+
+	t.Run("should return an error when the range is empty", func(t *testing.T) {
+		txn := db.Txn(false)
+		defer txn.Abort()
+
+		_, _, err := UserSvc{}.List(txn, false, TimeRange{After: day(2), Before: day(2)}, nil, Page{})
+		td.Cmp(t, err, TimeRangeInvalid)
+	})
+}
+
+func TestSearch_created(t *testing.T) {
+	db := NewDBOrPanic()
+	day := func(d int) time.Time { return time.Date(2020, 6, d, 0, 0, 0, 0, time.UTC) }
+	users := []User{
+		{ID: "a1", Email: "a@pod.ru", Age: 30, CreatedAt: day(3)},
+		{ID: "a2", Email: "b@pod.ru", Age: 20, CreatedAt: day(2)},
+		{ID: "a3", Email: "c@pod.ru", Age: 30, CreatedAt: day(1)},
+		{ID: "a4", Email: "d@email.org", Age: 30, CreatedAt: day(2)},
+	}
+
+	txn := db.Txn(true)
+	defer txn.Abort()
+	fillDBWith(users)(txn)
+
+	got, _, err := UserSvc{}.Search(txn, SearchQuery{EmailDomain: "pod.ru", Created: TimeRange{After: day(1)}}, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User{users[1], users[0]})
+
+	age := int32(30)
+	got, _, err = UserSvc{}.Search(txn, SearchQuery{AgeFrom: &age, Created: TimeRange{Before: day(3)}}, Page{})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User{users[2], users[3]})
+
+	_, _, err = UserSvc{}.Search(txn, SearchQuery{Created: TimeRange{After: day(3), Before: day(1)}}, Page{})
+	td.Cmp(t, err, TimeRangeInvalid)
+}
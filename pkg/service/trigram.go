@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	memdb "github.com/hashicorp/go-memdb"
+)
+
+// trigramIndex is a memdb indexer that indexes a user under each trigram
+// (sequence of three runes) of its folded first and last names. Since
+// memdb maintains the indexes when objects are inserted or deleted, the
+// trigrams are always in sync with the users, even within a transaction
+// that has not been committed yet.
+//
+// For example, "Maël" is indexed under "mae" and "ael".
+type trigramIndex struct{}
+
+func (trigramIndex) FromObject(raw interface{}) (bool, [][]byte, error) {
+	u, ok := raw.(*User)
+	if !ok {
+		return false, nil, fmt.Errorf("trigram index: expected a *User, got %T", raw)
+	}
+
+	set := make(map[string]struct{})
+	for _, name := range []string{u.FirstName, u.LastName} {
+		for _, tg := range trigrams(fold(name)) {
+			set[tg] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return false, nil, nil
+	}
+
+	vals := make([][]byte, 0, len(set))
+	for tg := range set {
+		vals = append(vals, []byte(tg+"\x00"))
+	}
+	sort.Slice(vals, func(i, j int) bool { return string(vals[i]) < string(vals[j]) })
+
+	return true, vals, nil
+}
+
+func (trigramIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("trigram index: must provide only a single argument")
+	}
+	tg, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("trigram index: argument must be a string: %#v", args[0])
+	}
+
+	// Add the null character as a terminator, like StringFieldIndex does,
+	// so that "abc" does not match "abcd".
+	return []byte(tg + "\x00"), nil
+}
+
+// trigrams returns the trigrams of s in order of appearance. Strings of
+// less than three runes have no trigrams.
+func trigrams(s string) []string {
+	r := []rune(s)
+	if len(r) < 3 {
+		return nil
+	}
+
+	tgs := make([]string, 0, len(r)-2)
+	for i := 0; i+3 <= len(r); i++ {
+		tgs = append(tgs, string(r[i:i+3]))
+	}
+	return tgs
+}
+
+// nameCandidates returns the users whose names contain all the trigrams
+// of the folded query, sorted by email. The candidates still have to
+// be checked using nameMatcher since having all the trigrams does not
+// mean that they appear in the right order. The boolean is false when
+// the query is too short to have trigrams, in which case the caller has
+// to go through all the users.
+func nameCandidates(txn *memdb.Txn, foldedQuery string) ([]*User, bool, error) {
+	tgs := trigrams(foldedQuery)
+	if len(tgs) == 0 {
+		return nil, false, nil
+	}
+
+	// We intersect the sets of users of each trigram, starting with the
+	// first trigram.
+	var candidates map[string]*User
+	for _, tg := range tgs {
+		it, err := txn.Get("user", "trigram", tg)
+		if err != nil {
+			return nil, false, err
+		}
+
+		found := make(map[string]*User)
+		for raw := it.Next(); raw != nil; raw = it.Next() {
+			u := raw.(*User)
+			if candidates == nil || candidates[u.ID] != nil {
+				found[u.ID] = u
+			}
+		}
+
+		candidates = found
+		if len(candidates) == 0 {
+			return nil, true, nil
+		}
+	}
+
+	users := make([]*User, 0, len(candidates))
+	for _, u := range candidates {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return strings.ToLower(users[i].Email) < strings.ToLower(users[j].Email) })
+
+	return users, true, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func Test_trigrams(t *testing.T) {
+	td.Cmp(t, trigrams("maël"), []string{"maë", "aël"})
+	td.Cmp(t, trigrams("abc"), []string{"abc"})
+	td.Cmp(t, trigrams("ab"), td.Nil())
+}
+
+func TestTrigramIndex(t *testing.T) {
+	db := NewDBOrPanic()
+	svc := UserSvc{Clock: func() time.Time { return writtenAt }}
+	users := []User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+		{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+		{FirstName: "Al", LastName: "Ng", Age: 30, ID: "d2f1e0a", Email: "al@ng.cn"},
+	}
+
+	t.Run("should use the trigram index for finding users", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, _, err := svc.SearchName(txn, "FLÔR", Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[2]})
+	})
+
+	t.Run("should only keep the users that contain the trigrams in the right order", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith([]User{{FirstName: "Anna", LastName: "Nana", ID: "e5f6a7b", Email: "anna@nana.org"}})(txn)
+
+		// All the trigrams of "annana" are in "Anna" or "Nana", but
+		// neither name contains "annana".
+		got, _, err := svc.SearchName(txn, "annana", Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Nil())
+	})
+
+	t.Run("should go through all the users when the query is too short", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		got, _, err := svc.SearchName(txn, "ng", Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{users[3]})
+	})
+
+	t.Run("should sort the users by email regardless of the case", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		mixed := []User{
+			{FirstName: "Lora", ID: "f1", Email: "Bob@lora.org"},
+			{FirstName: "Lora", ID: "f2", Email: "alice@lora.org"},
+			{FirstName: "Lora", ID: "f3", Email: "carol@lora.org"},
+		}
+		fillDBWith(mixed)(txn)
+
+		got, next, err := svc.SearchName(txn, "lora", Page{Size: 2})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{mixed[1], mixed[0]})
+
+		got, _, err = svc.SearchName(txn, "lora", Page{Size: 2, Token: next})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{mixed[2]})
+	})
+
+	t.Run("should be kept up to date when users are updated and deleted", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		_, err := svc.Update(txn, "le@rec.gb", []string{"name.first"}, User{FirstName: "Lorna"}, "")
+		td.CmpNoError(t, err)
+		_, err = svc.Delete(txn, "zikuwcus@awobik.kr", "", "")
+		td.CmpNoError(t, err)
+
+		got, _, err := svc.SearchName(txn, "lor", Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []User{{FirstName: "Lorna", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb", UpdatedAt: writtenAt, Version: 1}})
+
+		got, _, err = svc.SearchName(txn, "wayne", Page{})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, td.Nil())
+	})
+}
+
+// The benchmarks compare SearchName, which uses the trigram index, with
+// a scan of the whole email index, which is what SearchName used to do.
+//
+//	go test ./pkg/service -run=^$ -bench=SearchName
+func BenchmarkSearchName(b *testing.B) {
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	fillDBWith(randomUsers(100000))(txn)
+	txn.Commit()
+
+	// A query that matches few users, and another one that matches a lot
+	// of them.
+	for _, query := range []string{"kovaba", "ara"} {
+		b.Run(query+"/trigram-index", func(b *testing.B) {
+			txn := db.Txn(false)
+			for i := 0; i < b.N; i++ {
+				_, _, err := UserSvc{}.SearchName(txn, query, Page{})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(query+"/full-scan", func(b *testing.B) {
+			txn := db.Txn(false)
+			for i := 0; i < b.N; i++ {
+				var users []User
+				err := walkEmail(txn, "", filter(nameMatcher(query), func(u *User) bool {
+					users = append(users, *u)
+					return true
+				}))
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// randomUsers returns n users whose names are made of random syllables.
+// The same users are returned every time.
+func randomUsers(n int) []User {
+	r := rand.New(rand.NewSource(42))
+	syllables := []string{"ba", "ko", "ri", "na", "vé", "lu", "mo", "sa", "té", "di", "ra", "ël", "po", "ga", "zu", "fi"}
+	name := func() string {
+		var s string
+		for i := 0; i < 2+r.Intn(3); i++ {
+			s += syllables[r.Intn(len(syllables))]
+		}
+		return s
+	}
+
+	users := make([]User, n)
+	for i := range users {
+		users[i] = User{
+			ID:        fmt.Sprintf("%08x", i),
+			Age:       int32(18 + r.Intn(60)),
+			FirstName: name(),
+			LastName:  name(),
+			Email:     fmt.Sprintf("user%06d@email.org", i),
+		}
+	}
+	return users
+}
@@ -1,25 +1,32 @@
 package service
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"strings"
-	"unicode"
+	"time"
 
 	"github.com/sirupsen/logrus"
-	"golang.org/x/text/runes"
-	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
 
 	memdb "github.com/hashicorp/go-memdb"
+	"github.com/maelvls/users-grpc/pkg/userfields"
 	"github.com/rs/xid"
 )
 
 var (
 	EmailNotFound             = errors.New("email not found")
+	IDNotFound                = errors.New("id not found")
+	IDDoesNotMatchEmail       = errors.New("the given id does not match the user's id")
+	DeleteKeyEmpty            = errors.New("either the email or the id must be given")
+	EmailEmpty                = errors.New("email cannot be empty")
 	EmailAlreadyExists        = errors.New("email already exists")
+	IDAlreadyExists           = errors.New("id already exists")
 	NameQueryEmpty            = errors.New("name query cannot be empty")
 	AgeFromIsGreaterThanAgeTo = errors.New("the starting age must be lower or equal to the ending age")
+	PhoneQueryInvalid         = errors.New("the phone query must contain at least one digit")
+	UpdateMaskEmpty           = errors.New("the update mask cannot be empty")
+	UpdateFieldUnknown        = errors.New("unknown field in update mask")
 )
 
 // MemDB is a simple in-memory DB by Hashicorp. As I wanted to keep things
@@ -35,12 +42,84 @@ func NewDBOrPanic() *memdb.MemDB {
 			"user": {
 				Name: "user",
 				Indexes: map[string]*memdb.IndexSchema{
-					// The primary key is on 'email'; we have to call this index 'id'
-					// because go-memdb wants the table to have at least one 'id'
-					// index.
-					"id":    {Name: "id", Unique: true, Indexer: &memdb.StringFieldIndex{Field: "Email"}},
-					"email": {Name: "email", Unique: true, Indexer: &memdb.StringFieldIndex{Field: "Email"}},
-					"age":   {Name: "age", Unique: false, Indexer: &memdb.IntFieldIndex{Field: "Age"}},
+					// The primary key is the user's ID so that the email can
+					// be changed without losing the user's identity.
+					"id": {Name: "id", Unique: true, Indexer: &memdb.StringFieldIndex{Field: "ID"}},
+					// The emails are indexed in lowercase so that
+					// "Brianna.Shelton@email.org" and "brianna.shelton@email.org"
+					// are the same user; Email keeps the case it was given with.
+					"email": {Name: "email", Unique: true, Indexer: &memdb.StringFieldIndex{Field: "Email", Lowercase: true}},
+					// Users of the same age are sorted by email rather than
+					// by ID, which is what the non-unique index would do.
+					"age": {Name: "age", Unique: false, Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+						ageIndex{},
+						&memdb.StringFieldIndex{Field: "Email", Lowercase: true},
+					}}},
+					// Used by the name searches for only going through the
+					// users that may match. Users whose names are shorter
+					// than three characters are not in this index.
+					"trigram": {Name: "trigram", Unique: false, AllowMissing: true, Indexer: trigramIndex{}},
+					// Used by the phonetic name search. The codes are computed
+					// once when users are written rather than for each search.
+					"phonetic": {Name: "phonetic", Unique: false, AllowMissing: true, Indexer: phoneticIndex{}},
+					// Users without a phone number are not in this index.
+					"phone": {Name: "phone", Unique: true, AllowMissing: true, Indexer: &memdb.StringFieldIndex{Field: "PhoneE164"}},
+					// Like the age index, the users of the same city or
+					// region are sorted by email. Users without a city or a
+					// region are not in these indexes.
+					"city": {Name: "city", Unique: false, AllowMissing: true, Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+						cityIndex,
+						&memdb.StringFieldIndex{Field: "Email", Lowercase: true},
+					}}},
+					"region": {Name: "region", Unique: false, AllowMissing: true, Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+						regionIndex,
+						&memdb.StringFieldIndex{Field: "Email", Lowercase: true},
+					}}},
+					// Each label is indexed both by its key alone and by
+					// its key and value: since the index is not unique,
+					// memdb appends the id to each entry and the lookups
+					// are prefix lookups, which means that looking up
+					// "team" finds all the entries "team\x00<value>\x00".
+					// Users without labels are not in this index.
+					"label": {Name: "label", Unique: false, AllowMissing: true, Indexer: &memdb.StringMapFieldIndex{Field: "Labels"}},
+					// Used for finding the direct reports of a manager,
+					// sorted by email. Users without a manager are not in
+					// this index.
+					"manager": {Name: "manager", Unique: false, AllowMissing: true, Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{Field: "ManagerID"},
+						&memdb.StringFieldIndex{Field: "Email", Lowercase: true},
+					}}},
+					// Like the age index, the users created or updated at
+					// the same time are sorted by email. Users without
+					// timestamps, which are only found in the tests, are
+					// not in these indexes.
+					"created": {Name: "created", Unique: false, AllowMissing: true, Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+						createdIndex,
+						&memdb.StringFieldIndex{Field: "Email", Lowercase: true},
+					}}},
+					"updated": {Name: "updated", Unique: false, AllowMissing: true, Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+						updatedIndex,
+						&memdb.StringFieldIndex{Field: "Email", Lowercase: true},
+					}}},
+				},
+			},
+			"group": {
+				Name: "group",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {Name: "id", Unique: true, Indexer: &memdb.StringFieldIndex{Field: "ID"}},
+					// Like the emails, the group names are case-insensitive
+					// but are displayed as given.
+					"name": {Name: "name", Unique: true, Indexer: &memdb.StringFieldIndex{Field: "Name", Lowercase: true}},
+					// Used for finding the groups of a user. Groups without
+					// members are not in this index.
+					"member": {Name: "member", Unique: false, AllowMissing: true, Indexer: &memdb.StringSliceFieldIndex{Field: "Members"}},
+				},
+			},
+			"credential": {
+				Name: "credential",
+				Indexes: map[string]*memdb.IndexSchema{
+					// At most one password per user.
+					"id": {Name: "id", Unique: true, Indexer: &memdb.StringFieldIndex{Field: "UserID"}},
 				},
 			},
 		},
@@ -54,32 +133,92 @@ func NewDBOrPanic() *memdb.MemDB {
 }
 
 type User struct {
-	ID        string `json:"id,omitempty"`
-	Age       int32  `json:"age,omitempty"`
-	FirstName string `json:"firstName,omitempty"`
-	LastName  string `json:"lastName,omitempty"`
-	Email     string `json:"email,omitempty"`
-	Phone     string `json:"phone,omitempty"`
-	Address   string `json:"address,omitempty"`
+	ID        string  `json:"id,omitempty"`
+	Age       int32   `json:"age,omitempty"`
+	FirstName string  `json:"firstName,omitempty"`
+	LastName  string  `json:"lastName,omitempty"`
+	Email     string  `json:"email,omitempty"`
+	Phone     string  `json:"phone,omitempty"`     // As given, e.g. "+1 (906) 568-2594".
+	PhoneE164 string  `json:"phoneE164,omitempty"` // Computed from Phone, e.g. "+19065682594".
+	Address   Address `json:"address"`
+
+	// Free-form labels such as "team": "payments"; see ParseSelector.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// The ID of the user this user reports to, if any; see OrgTree.
+	ManagerID string `json:"managerId,omitempty"`
+
+	// Set by the service using the clock, see now. UpdatedAt changes each
+	// time the user is written, including when it is deleted or restored.
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Set when the user is soft-deleted, see Delete.
+	DeletedAt time.Time `json:"deletedAt"`
+
+	// Starts at 1 and is incremented each time the user is written; see
+	// Etag.
+	Version int64 `json:"version,omitempty"`
 }
 
 // This struct is meant to make the service mockable for testing purposes.
 // If I didn't need to test this, I would go with plain functions.
-type UserSvc struct{}
+type UserSvc struct {
+	// Clock gives the time of the timestamps and of the lockouts. When
+	// nil, time.Now is used.
+	Clock func() time.Time
+}
+
+func (svc UserSvc) now() time.Time {
+	if svc.Clock == nil {
+		return time.Now()
+	}
+	return svc.Clock()
+}
 
 // Create a user. The transaction must be created with write mode. If the
 // given user has no ID, one will be generated randomly using the Mongo
 // Object ID algorithm, see:
 // https://docs.mongodb.com/manual/reference/method/ObjectId/
 //
-// The possible error is EmailAlreadyExists.
-func (UserSvc) Create(txn *memdb.Txn, user User) error {
+// The email must be unique regardless of its case. The phone number is
+// normalized to E.164 into PhoneE164 while Phone is kept as given. The
+// version of the user is set to 1 and both CreatedAt and UpdatedAt are set
+// to the current time.
+//
+// When ManagerID is given, it must be the ID of a user that is not
+// deleted.
+//
+// Possible errors: EmailEmpty, EmailInvalid, EmailAlreadyExists,
+// IDAlreadyExists, PhoneInvalid, PhoneAlreadyExists, LabelInvalid,
+// ManagerNotFound, ManagerCycle.
+func (svc UserSvc) Create(txn *memdb.Txn, user User) error {
+	err := validateEmail(user.Email)
+	if err != nil {
+		return err
+	}
+
+	err = userfields.ValidateLabels(user.Labels)
+	if err != nil {
+		return err
+	}
+
 	if user.ID == "" {
 		user.ID = xid.New().String()
 	}
 
+	// Since the ID is the primary key, inserting a user with an existing
+	// ID would silently replace the existing user.
+	raw, err := txn.First("user", "id", user.ID)
+	if err != nil {
+		return fmt.Errorf("finding if the id %s is already used: %w", user.ID, err)
+	}
+	if raw != nil {
+		return IDAlreadyExists
+	}
+
 	// Let's make sure this email doesn't already exist.
-	raw, err := txn.First("user", "email", user.Email)
+	raw, err = txn.First("user", "email", user.Email)
 	if err != nil {
 		return fmt.Errorf("finding if the email %s is already used: %w", user.Email, err)
 	}
@@ -87,6 +226,19 @@ func (UserSvc) Create(txn *memdb.Txn, user User) error {
 		return EmailAlreadyExists
 	}
 
+	err = setPhone(txn, &user)
+	if err != nil {
+		return err
+	}
+
+	err = checkManager(txn, &user, "")
+	if err != nil {
+		return err
+	}
+
+	user.Version = 1
+	user.CreatedAt = svc.now()
+	user.UpdatedAt = user.CreatedAt
 	err = txn.Insert("user", &user)
 	if err != nil {
 		return fmt.Errorf("inserting user %s: %w", user.Email, err)
@@ -95,106 +247,308 @@ func (UserSvc) Create(txn *memdb.Txn, user User) error {
 	return nil
 }
 
-// List all users.
-func (UserSvc) List(txn *memdb.Txn) ([]User, error) {
-	it, err := txn.Get("user", "email")
+// List all users, sorted by email unless page.OrderBy is given. The
+// soft-deleted users are only listed when includeDeleted is true. When the
+// created range is not zero, only the users created in this range are
+// listed and they are sorted by creation time and then by email. Only the
+// users whose labels match the selector are listed. The page tells which
+// part of the list must be returned; the token of the next page is
+// returned along with the users and is empty when there are no more users.
+//
+// Possible errors: TimeRangeInvalid, PageSizeNegative, InvalidPageToken,
+// OrderByInvalid.
+func (UserSvc) List(txn *memdb.Txn, includeDeleted bool, created TimeRange, selector Selector, page Page) ([]User, string, error) {
+	err := created.validate()
 	if err != nil {
-		return nil, fmt.Errorf("list users: %w", err)
+		return nil, "", err
 	}
 
-	var users []User
-	for raw := it.Next(); raw != nil; raw = it.Next() {
-		user := raw.(*User)
-		users = append(users, *user)
+	p, err := newPager(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fn := filter(visible(includeDeleted), p.add)
+	switch {
+	case !created.IsZero():
+		err = walkCreatedRange(txn, created, p.start, filter(selector.matcher(), fn))
+	case len(selector) > 0:
+		err = walkLabels(txn, selector, p.start.Email, fn)
+	default:
+		err = walkEmail(txn, p.start.Email, fn)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("list users: %w", err)
+	}
+
+	users, next := p.finish()
+	return users, next, nil
+}
+
+// StreamList calls fn for each user while the index is being walked; no
+// list of users is built in memory. The users are filtered and sorted the
+// same way as with List. The walk stops at the first error returned by
+// fn, and this error is returned.
+//
+// Possible errors: TimeRangeInvalid.
+func (UserSvc) StreamList(txn *memdb.Txn, includeDeleted bool, created TimeRange, selector Selector, fn func(User) error) error {
+	err := created.validate()
+	if err != nil {
+		return err
 	}
 
-	return users, nil
+	var fnErr error
+	each := filter(visible(includeDeleted), untilErr(fn, &fnErr))
+	switch {
+	case !created.IsZero():
+		err = walkCreatedRange(txn, created, cursor{}, filter(selector.matcher(), each))
+	case len(selector) > 0:
+		err = walkLabels(txn, selector, "", each)
+	default:
+		err = walkEmail(txn, "", each)
+	}
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+
+	return fnErr
 }
 
 // SearchAge searches all users in the range [from, to_included]. The
-// possible error is AgeFromIsGreaterThanAgeTo.
-func (UserSvc) SearchAge(txn *memdb.Txn, ageFrom, ageTo int32) ([]User, error) {
+// users are sorted by age and then by email. See List for how page
+// works.
+//
+// Possible errors: AgeFromIsGreaterThanAgeTo, PageSizeNegative,
+// InvalidPageToken, OrderByInvalid.
+func (UserSvc) SearchAge(txn *memdb.Txn, ageFrom, ageTo int32, page Page) ([]User, string, error) {
 	if ageFrom > ageTo {
-		return nil, AgeFromIsGreaterThanAgeTo
+		return nil, "", AgeFromIsGreaterThanAgeTo
 	}
 
-	// Range scan over people with ages in a range.
-	it, err := txn.LowerBound("user", "age", ageFrom)
+	p, err := newPager(page)
 	if err != nil {
-		return nil, fmt.Errorf("listing users starting at age %d: %w", ageFrom, err)
+		return nil, "", err
 	}
 
-	var users []User
-	for raw := it.Next(); raw != nil; raw = it.Next() {
-		u := raw.(*User)
-		// Filter out all users that beyond the upper limit.
-		if u.Age > ageTo {
-			break
-		}
-		users = append(users, *u)
+	// When we are resuming from a previous page, we start where the
+	// previous page stopped.
+	startAge, startEmail := ageFrom, ""
+	if p.start.Email != "" && p.start.Age >= ageFrom {
+		startAge, startEmail = p.start.Age, p.start.Email
+	}
+
+	err = walkAge(txn, startAge, startEmail, ageTo, filter(notDeleted, p.add))
+	if err != nil {
+		return nil, "", fmt.Errorf("listing users starting at age %d: %w", startAge, err)
+	}
+
+	users, next := p.finish()
+	return users, next, nil
+}
+
+// StreamSearchAge is the streaming counterpart of SearchAge. See
+// StreamList for how fn is called.
+//
+// Possible errors: AgeFromIsGreaterThanAgeTo.
+func (UserSvc) StreamSearchAge(txn *memdb.Txn, ageFrom, ageTo int32, fn func(User) error) error {
+	if ageFrom > ageTo {
+		return AgeFromIsGreaterThanAgeTo
+	}
+
+	var fnErr error
+	err := walkAge(txn, ageFrom, "", ageTo, filter(notDeleted, untilErr(fn, &fnErr)))
+	if err != nil {
+		return fmt.Errorf("listing users starting at age %d: %w", ageFrom, err)
 	}
 
-	return users, nil
+	return fnErr
 }
 
 // SearchName searches a user by a part of its first or last name. The
 // search is case-insensitive and diacritics are normalised into ASCII
 // characters. For example, 'mael' will return 'Maël' if the record exists.
+// The users are sorted by email. See List for how page works.
+//
+// Possible errors: NameQueryEmpty, PageSizeNegative, InvalidPageToken,
+// OrderByInvalid.
+func (UserSvc) SearchName(txn *memdb.Txn, query string, page Page) ([]User, string, error) {
+	if query == "" {
+		return nil, "", NameQueryEmpty
+	}
+
+	p, err := newPager(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = walkName(txn, query, p.start.Email, filter(notDeleted, p.add))
+	if err != nil {
+		return nil, "", fmt.Errorf("err when getting data from db: %w", err)
+	}
+
+	users, next := p.finish()
+	return users, next, nil
+}
+
+// StreamSearchName is the streaming counterpart of SearchName. See
+// StreamList for how fn is called.
 //
 // Possible errors: NameQueryEmpty.
-func (UserSvc) SearchName(txn *memdb.Txn, query string) ([]User, error) {
+func (UserSvc) StreamSearchName(txn *memdb.Txn, query string, fn func(User) error) error {
 	if query == "" {
-		return nil, NameQueryEmpty
+		return NameQueryEmpty
 	}
 
-	// Let's convert diacritics into ASCII characters. We simply remove the
-	// unicode runes that belong to the "Mn" set (Mark, nonspacing).
-	// https://stackoverflow.com/questions/26722450/remove-diacritics-using-go
-	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	var fnErr error
+	err := walkName(txn, query, "", filter(notDeleted, untilErr(fn, &fnErr)))
+	if err != nil {
+		return fmt.Errorf("err when getting data from db: %w", err)
+	}
 
-	logrus.Debugf("searching the substring '%s'", query)
-	query, _, _ = transform.String(t, strings.ToLower(query))
-	logrus.Debugf("normalized substring: '%s'", query)
-
-	// This function filters out all users that do not contain the given
-	// substr. Elmts are filtered/skipped when this function returns true.
-	// This function should return false when an element should be kept.
-	filterByFirstOrLastName := func(query string) func(interface{}) bool {
-		return func(raw interface{}) bool {
-			u, ok := raw.(*User)
-			if !ok {
-				logrus.Errorf("could not unpack a User, instead got: %#+v", raw)
-				return true // Skip this element.
-			}
+	return fnErr
+}
+
+// The walk functions go through an index starting at the given position
+// and call fn for each user until fn returns false.
 
-			first, _, _ := transform.String(t, strings.ToLower(u.FirstName))
-			last, _, _ := transform.String(t, strings.ToLower(u.LastName))
+// walkEmail walks the email index starting at the given email.
+func walkEmail(txn *memdb.Txn, fromEmail string, fn func(*User) bool) error {
+	it, err := txn.LowerBound("user", "email", fromEmail)
+	if err != nil {
+		return err
+	}
 
-			hasSubstr := strings.Contains(first, query) || strings.Contains(last, query)
-			// We skip the element whenever the substr has not been matched.
-			pleaseSkipIt := !hasSubstr
-			return pleaseSkipIt
+	for raw := it.Next(); raw != nil; raw = it.Next() {
+		if !fn(raw.(*User)) {
+			break
 		}
 	}
 
-	result, err := txn.Get("user", "email")
-	if err != nil {
-		return nil, fmt.Errorf("err when getting data from db: %e", err)
+	return nil
+}
+
+// ageIndex is a memdb indexer for the ages. Unlike memdb.IntFieldIndex,
+// which encodes the integers as varints, the byte order is the order of
+// the ages, negative ones included.
+type ageIndex struct{}
+
+func (ageIndex) FromObject(raw interface{}) (bool, []byte, error) {
+	u, ok := raw.(*User)
+	if !ok {
+		return false, nil, fmt.Errorf("age index: expected a *User, got %T", raw)
+	}
+
+	return true, encodeAge(u.Age), nil
+}
+
+func (ageIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("age index: must provide only a single argument")
+	}
+	age, ok := args[0].(int32)
+	if !ok {
+		return nil, fmt.Errorf("age index: argument must be an int32: %#v", args[0])
 	}
 
-	it := memdb.NewFilterIterator(result, filterByFirstOrLastName(query))
+	return encodeAge(age), nil
+}
+
+// encodeAge gives the age in big endian with its sign bit flipped, the
+// same way as encodeTime.
+func encodeAge(age int32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(age)^(1<<31))
+	return b
+}
+
+// walkAge does a range scan over the age index, starting at the user of
+// age fromAge with the email fromEmail and stopping after toAge.
+func walkAge(txn *memdb.Txn, fromAge int32, fromEmail string, toAge int32, fn func(*User) bool) error {
+	it, err := txn.LowerBound("user", "age", fromAge, fromEmail)
+	if err != nil {
+		return err
+	}
 
-	var users []User
 	for raw := it.Next(); raw != nil; raw = it.Next() {
 		u := raw.(*User)
-		users = append(users, *u)
+		// Filter out all users that beyond the upper limit.
+		if u.Age > toAge {
+			break
+		}
+		if !fn(u) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// walkName goes through the users sorted by email starting at the given
+// email and only calls fn for the users whose first or last name contain
+// the query. The trigram index is used for narrowing down the users that
+// may match; when the query is shorter than three characters, the whole
+// email index is walked instead.
+func walkName(txn *memdb.Txn, query, fromEmail string, fn func(*User) bool) error {
+	fn = filter(nameMatcher(query), fn)
+
+	candidates, ok, err := nameCandidates(txn, fold(query))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return walkEmail(txn, fromEmail, fn)
 	}
 
-	return users, nil
+	for _, u := range candidates {
+		if strings.ToLower(u.Email) < strings.ToLower(fromEmail) {
+			continue
+		}
+		if !fn(u) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// filter returns a function that only calls fn with the users that match.
+func filter(match func(*User) bool, fn func(*User) bool) func(*User) bool {
+	return func(u *User) bool {
+		if !match(u) {
+			return true // Skip this user but keep walking.
+		}
+		return fn(u)
+	}
+}
+
+// nameMatcher returns a function that tells whether the first or the last
+// name of a user contains the query. Both are folded beforehand, which
+// means that the comparison is case-insensitive and diacritics are
+// ignored; see fold.
+func nameMatcher(query string) func(*User) bool {
+	logrus.Debugf("searching the substring '%s'", query)
+	query = fold(query)
+	logrus.Debugf("folded substring: '%s'", query)
+
+	return func(u *User) bool {
+		return strings.Contains(fold(u.FirstName), query) || strings.Contains(fold(u.LastName), query)
+	}
 }
 
-// GetByEmail returns a user by its email. May return EmailNotFound.
-func (UserSvc) GetByEmail(txn *memdb.Txn, email string) (User, error) {
+// untilErr turns fn into a function that can be given to the walk
+// functions. The walk stops at the first error returned by fn, and this
+// error is stored in fnErr.
+func untilErr(fn func(User) error, fnErr *error) func(*User) bool {
+	return func(u *User) bool {
+		*fnErr = fn(*u)
+		return *fnErr == nil
+	}
+}
+
+// GetByEmail returns a user by its email, ignoring the case. A
+// soft-deleted user is only returned when includeDeleted is true. May
+// return EmailNotFound.
+func (UserSvc) GetByEmail(txn *memdb.Txn, email string, includeDeleted bool) (User, error) {
 	raw, err := txn.First("user", "email", email)
 
 	if err != nil {
@@ -202,7 +556,7 @@ func (UserSvc) GetByEmail(txn *memdb.Txn, email string) (User, error) {
 	}
 
 	// When not found, gracefully return 'email not found'
-	if raw == nil {
+	if raw == nil || !visible(includeDeleted)(raw.(*User)) {
 		return User{}, EmailNotFound
 	}
 
@@ -213,3 +567,228 @@ func (UserSvc) GetByEmail(txn *memdb.Txn, email string) (User, error) {
 
 	return *user, nil
 }
+
+// Update changes the given fields of the user identified by email. The
+// fields are the paths of the update mask, e.g. "age", "name.first" or
+// "phone"; only these fields are copied from the given user. The path
+// "labels" replaces all the labels while "labels.<key>" only sets this
+// label, or removes it when the given user does not have it. The path
+// "manager_id" changes the manager, or removes it when empty. The email
+// and the ID cannot be updated. When etag is not empty, the user must still
+// have this etag, see User.Etag. The transaction must be created with write
+// mode.
+//
+// Possible errors: EmailNotFound, UpdateMaskEmpty, UpdateFieldUnknown,
+// PhoneInvalid, PhoneAlreadyExists, LabelInvalid, ManagerNotFound,
+// ManagerCycle, EtagInvalid, VersionMismatch.
+func (svc UserSvc) Update(txn *memdb.Txn, email string, fields []string, user User, etag string) (User, error) {
+	if len(fields) == 0 {
+		return User{}, UpdateMaskEmpty
+	}
+
+	found, err := firstActive(txn, "email", email)
+	if err != nil {
+		return User{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+	}
+	if found == nil {
+		return User{}, EmailNotFound
+	}
+	err = checkEtag(found, etag)
+	if err != nil {
+		return User{}, err
+	}
+
+	// Objects stored in memdb must never be modified in place, which is
+	// why we work on a copy.
+	updated := *found
+	updated.Labels = copyLabels(found.Labels)
+	touch(&updated, svc.now())
+	for _, field := range fields {
+		if key := strings.TrimPrefix(field, "labels."); key != field {
+			value, ok := user.Labels[key]
+			switch {
+			case ok && updated.Labels == nil:
+				updated.Labels = map[string]string{key: value}
+			case ok:
+				updated.Labels[key] = value
+			default:
+				delete(updated.Labels, key)
+			}
+			continue
+		}
+
+		switch field {
+		case "age":
+			updated.Age = user.Age
+		case "name":
+			updated.FirstName = user.FirstName
+			updated.LastName = user.LastName
+		case "name.first":
+			updated.FirstName = user.FirstName
+		case "name.last":
+			updated.LastName = user.LastName
+		case "phone":
+			updated.Phone = user.Phone
+		case "address":
+			updated.Address = user.Address
+		case "address.street":
+			updated.Address.Street = user.Address.Street
+		case "address.city":
+			updated.Address.City = user.Address.City
+		case "address.region":
+			updated.Address.Region = user.Address.Region
+		case "address.postal_code":
+			updated.Address.PostalCode = user.Address.PostalCode
+		case "address.country":
+			updated.Address.Country = user.Address.Country
+		case "labels":
+			updated.Labels = copyLabels(user.Labels)
+		case "manager_id":
+			updated.ManagerID = user.ManagerID
+		default:
+			return User{}, fmt.Errorf("%w: %s", UpdateFieldUnknown, field)
+		}
+	}
+
+	err = userfields.ValidateLabels(updated.Labels)
+	if err != nil {
+		return User{}, err
+	}
+
+	err = setPhone(txn, &updated)
+	if err != nil {
+		return User{}, err
+	}
+
+	err = checkManager(txn, &updated, found.ManagerID)
+	if err != nil {
+		return User{}, err
+	}
+
+	err = txn.Insert("user", &updated)
+	if err != nil {
+		return User{}, fmt.Errorf("updating user %s: %w", email, err)
+	}
+
+	return updated, nil
+}
+
+// Delete soft-deletes a user using its email or its id: the user is
+// hidden from the reads but can be brought back using Restore. Its email
+// and its phone cannot be used by another user until it is purged, see
+// Purge. When both the email and the id are given, the user found by email
+// must have the given id. When etag is not empty, the user must still have
+// this etag. The user stays in its groups and keeps its reports, where
+// it is hidden until it is restored; see DirectReports. The transaction
+// must be created with write mode. The deleted user is returned.
+//
+// Possible errors: DeleteKeyEmpty, EmailNotFound, IDNotFound,
+// IDDoesNotMatchEmail, EtagInvalid, VersionMismatch.
+func (svc UserSvc) Delete(txn *memdb.Txn, email, id, etag string) (User, error) {
+	var found *User
+	var err error
+	switch {
+	case email != "":
+		found, err = firstActive(txn, "email", email)
+		if err != nil {
+			return User{}, fmt.Errorf("finding the user with email %s: %w", email, err)
+		}
+		if found == nil {
+			return User{}, EmailNotFound
+		}
+		if id != "" && found.ID != id {
+			return User{}, IDDoesNotMatchEmail
+		}
+	case id != "":
+		found, err = firstActive(txn, "id", id)
+		if err != nil {
+			return User{}, fmt.Errorf("finding the user with id %s: %w", id, err)
+		}
+		if found == nil {
+			return User{}, IDNotFound
+		}
+	default:
+		return User{}, DeleteKeyEmpty
+	}
+	err = checkEtag(found, etag)
+	if err != nil {
+		return User{}, err
+	}
+
+	deleted := *found
+	touch(&deleted, svc.now())
+	deleted.DeletedAt = deleted.UpdatedAt
+	err = txn.Insert("user", &deleted)
+	if err != nil {
+		return User{}, fmt.Errorf("deleting user %s: %w", deleted.Email, err)
+	}
+
+	return deleted, nil
+}
+
+// ChangeEmail changes the email of the user identified by oldEmail. The
+// user keeps the same ID. When etag is not empty, the user must still have
+// this etag. The transaction must be created with write mode. Changing
+// only the case of the email is allowed.
+//
+// Possible errors: EmailEmpty, EmailInvalid, EmailNotFound,
+// EmailAlreadyExists, EtagInvalid, VersionMismatch.
+func (svc UserSvc) ChangeEmail(txn *memdb.Txn, oldEmail, newEmail, etag string) (User, error) {
+	err := validateEmail(newEmail)
+	if err != nil {
+		return User{}, err
+	}
+
+	old, err := firstActive(txn, "email", oldEmail)
+	if err != nil {
+		return User{}, fmt.Errorf("finding the user with email %s: %w", oldEmail, err)
+	}
+	if old == nil {
+		return User{}, EmailNotFound
+	}
+	err = checkEtag(old, etag)
+	if err != nil {
+		return User{}, err
+	}
+
+	if newEmail == old.Email {
+		return *old, nil
+	}
+
+	// The soft-deleted users keep their emails until they are purged.
+	raw, err := txn.First("user", "email", newEmail)
+	if err != nil {
+		return User{}, fmt.Errorf("finding if the email %s is already used: %w", newEmail, err)
+	}
+	// Changing the case of the email is fine.
+	if raw != nil && raw.(*User).ID != old.ID {
+		return User{}, EmailAlreadyExists
+	}
+
+	// Since the ID is the primary key, inserting replaces the existing
+	// record and the email index gets updated accordingly.
+	updated := *old
+	updated.Email = newEmail
+	touch(&updated, svc.now())
+	err = txn.Insert("user", &updated)
+	if err != nil {
+		return User{}, fmt.Errorf("inserting user %s: %w", newEmail, err)
+	}
+
+	return updated, nil
+}
+
+// GetByID returns a user by its ID. The soft-deleted users are not
+// returned. May return IDNotFound.
+func (UserSvc) GetByID(txn *memdb.Txn, id string) (User, error) {
+	user, err := firstActive(txn, "id", id)
+	if err != nil {
+		return User{}, fmt.Errorf("finding the user with id %s: %w", id, err)
+	}
+
+	if user == nil {
+		return User{}, IDNotFound
+	}
+
+	return *user, nil
+}
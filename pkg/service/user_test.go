@@ -2,7 +2,9 @@ package service
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	td "github.com/maxatome/go-testdeep/td"
@@ -27,6 +29,7 @@ func TestNewDB(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	db := NewDBOrPanic()
+	svc := UserSvc{Clock: func() time.Time { return writtenAt }}
 
 	tests := []struct {
 		name        string
@@ -46,10 +49,10 @@ func TestCreate(t *testing.T) {
 			fieldChecks: td.StructFields{},
 			postChecks: func(t *testing.T, txn *memdb.Txn) {
 				// Check that the user exists.
-				raw, err := txn.First("user", "id", "zikuwcus@awobik.kr")
+				raw, err := txn.First("user", "id", "a4bcd38")
 				if td.CmpNoError(t, err) && td.CmpNotNil(t, raw) {
 					user := raw.(*User)
-					td.Cmp(t, User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"}, *user)
+					td.Cmp(t, User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr", CreatedAt: writtenAt, UpdatedAt: writtenAt, Version: 1}, *user)
 				}
 			},
 		},
@@ -70,6 +73,59 @@ func TestCreate(t *testing.T) {
 			wantErr:     EmailAlreadyExists,
 			fieldChecks: td.StructFields{},
 		},
+		{
+			name: "when a user is created with an email that only differs by its case, it should fail",
+			init: fillDBWith([]User{
+				{FirstName: "Brianna", LastName: "Shelton", Age: 52, ID: "ba3d530", Email: "brianna.shelton@email.org"},
+			}),
+			createUser:  User{FirstName: "Brianna", LastName: "Shelton", Age: 52, Email: "Brianna.Shelton@email.org"},
+			wantErr:     EmailAlreadyExists,
+			fieldChecks: td.StructFields{},
+		},
+		{
+			name:        "when a user is created with a malformed email, it should fail",
+			init:        fillDBWith([]User{}),
+			createUser:  User{FirstName: "Flora", LastName: "Hale", Age: 38, Email: "zikuwcus.awobik.kr"},
+			wantErr:     EmailInvalid,
+			fieldChecks: td.StructFields{},
+		},
+		{
+			name: "when a user is created with an id that already exists, it should fail",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			createUser:  User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "ba3d530", Email: "zikuwcus@awobik.kr"},
+			wantErr:     IDAlreadyExists,
+			fieldChecks: td.StructFields{},
+		},
+		{
+			name:        "when a user is created with a phone, it should be normalized to E.164",
+			init:        fillDBWith([]User{}),
+			createUser:  User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Phone: "+1 (906) 568-2594"},
+			fieldChecks: td.StructFields{},
+			postChecks: func(t *testing.T, txn *memdb.Txn) {
+				raw, err := txn.First("user", "phone", "+19065682594")
+				if td.CmpNoError(t, err) && td.CmpNotNil(t, raw) {
+					td.Cmp(t, *raw.(*User), User{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594", CreatedAt: writtenAt, UpdatedAt: writtenAt, Version: 1})
+				}
+			},
+		},
+		{
+			name: "when a user is created with a phone that already exists, it should fail",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594"},
+			}),
+			createUser:  User{FirstName: "Flora", LastName: "Hale", Age: 38, Email: "zikuwcus@awobik.kr", Phone: "(906) 568 2594"},
+			wantErr:     PhoneAlreadyExists,
+			fieldChecks: td.StructFields{},
+		},
+		{
+			name:        "when a user is created with an invalid phone, it should fail",
+			init:        fillDBWith([]User{}),
+			createUser:  User{FirstName: "Flora", LastName: "Hale", Age: 38, Email: "zikuwcus@awobik.kr", Phone: "12"},
+			wantErr:     PhoneInvalid,
+			fieldChecks: td.StructFields{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -78,7 +134,7 @@ func TestCreate(t *testing.T) {
 
 			tt.init(txn)
 
-			gotErr := UserSvc{}.Create(txn, tt.createUser)
+			gotErr := svc.Create(txn, tt.createUser)
 
 			if tt.wantErr != nil {
 				td.Cmp(t, gotErr, tt.wantErr)
@@ -127,7 +183,7 @@ func TestList(t *testing.T) {
 
 			tt.init(txn)
 
-			got, gotErr := UserSvc{}.List(txn)
+			got, _, gotErr := UserSvc{}.List(txn, false, TimeRange{}, nil, Page{})
 
 			if tt.wantErr != nil {
 				td.Cmp(t, gotErr, tt.wantErr)
@@ -178,7 +234,7 @@ func TestSearchAge(t *testing.T) {
 
 			tt.init(txn)
 
-			got, gotErr := UserSvc{}.SearchAge(txn, tt.ageFrom, tt.ageTo)
+			got, _, gotErr := UserSvc{}.SearchAge(txn, tt.ageFrom, tt.ageTo, Page{})
 			if tt.wantErr != nil {
 				td.Cmp(t, gotErr, tt.wantErr)
 				return
@@ -234,7 +290,7 @@ func TestSearchName(t *testing.T) {
 
 			tt.init(txn)
 
-			got, gotErr := UserSvc{}.SearchName(txn, tt.searchName)
+			got, _, gotErr := UserSvc{}.SearchName(txn, tt.searchName, Page{})
 			if tt.wantErr != nil {
 				td.Cmp(t, gotErr, tt.wantErr)
 				return
@@ -280,6 +336,14 @@ func TestGetByEmail(t *testing.T) {
 			getEmail: "wayne.keller@rec.gb",
 			want:     User{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "wayne.keller@rec.gb"},
 		},
+		{
+			name: "should ignore the case of the email and keep the original one",
+			init: fillDBWith([]User{
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "Wayne.Keller@rec.gb"},
+			}),
+			getEmail: "WAYNE.keller@REC.gb",
+			want:     User{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "Wayne.Keller@rec.gb"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -288,7 +352,7 @@ func TestGetByEmail(t *testing.T) {
 
 			tt.init(txn)
 
-			got, gotErr := UserSvc{}.GetByEmail(txn, tt.getEmail)
+			got, gotErr := UserSvc{}.GetByEmail(txn, tt.getEmail, false)
 			if tt.wantErr != nil {
 				td.Cmp(t, gotErr, tt.wantErr)
 				return
@@ -299,3 +363,504 @@ func TestGetByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate(t *testing.T) {
+	db := NewDBOrPanic()
+	svc := UserSvc{Clock: func() time.Time { return writtenAt }}
+
+	tests := []struct {
+		name        string
+		init        func(txn *memdb.Txn)
+		givenEmail  string
+		givenFields []string
+		givenUser   User
+		want        User
+		wantErr     error
+	}{
+		{
+			name: "should only update the fields given in the mask",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594"},
+			}),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"age", "address"},
+			givenUser:   User{FirstName: "Ignored", Age: 22, Address: Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}},
+			want:        User{FirstName: "Elnora", LastName: "Morales", Age: 22, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594", Address: Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}, UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should only update the address fields given in the mask",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Address: Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}},
+			}),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"address.city", "address.postal_code"},
+			givenUser:   User{Address: Address{Street: "Ignored", City: "Hammond", PostalCode: "46320"}},
+			want:        User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Address: Address{Street: "255 Cortelyou Road", City: "Hammond", Region: "Indiana", PostalCode: "46320"}, UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should normalize the phone when it is updated",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594"},
+			}),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"phone"},
+			givenUser:   User{Phone: "+33 6 12 34 56 78"},
+			want:        User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", Phone: "+33 6 12 34 56 78", PhoneE164: "+33612345678", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should return an error when the new phone is already used",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb", Phone: "+1 (906) 568-2594", PhoneE164: "+19065682594"},
+			}),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"phone"},
+			givenUser:   User{Phone: "906.568.2594"},
+			wantErr:     PhoneAlreadyExists,
+		},
+		{
+			name: "should return an error when the new phone is invalid",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"phone"},
+			givenUser:   User{Phone: "not a phone"},
+			wantErr:     PhoneInvalid,
+		},
+		{
+			name: "should update both first and last names when 'name' is given",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"name"},
+			givenUser:   User{FirstName: "Flora", LastName: "Hale"},
+			want:        User{FirstName: "Flora", LastName: "Hale", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name:        "should return an error when no user has this email",
+			init:        fillDBWith(nil),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"age"},
+			wantErr:     EmailNotFound,
+		},
+		{
+			name:        "should return an error when the mask is empty",
+			init:        fillDBWith(nil),
+			givenEmail:  "eza@pod.ru",
+			givenFields: nil,
+			wantErr:     UpdateMaskEmpty,
+		},
+		{
+			name: "should return an error when a field cannot be updated",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			givenEmail:  "eza@pod.ru",
+			givenFields: []string{"email"},
+			wantErr:     fmt.Errorf("unknown field in update mask: email"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+
+			tt.init(txn)
+
+			got, gotErr := svc.Update(txn, tt.givenEmail, tt.givenFields, tt.givenUser, "")
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, td.String(tt.wantErr.Error()))
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+
+				// The update must be visible in the DB too.
+				inDB, err := svc.GetByEmail(txn, tt.givenEmail, false)
+				if td.CmpNoError(t, err) {
+					td.Cmp(t, inDB, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db := NewDBOrPanic()
+	svc := UserSvc{Clock: func() time.Time { return deletedAt }}
+
+	tests := []struct {
+		name       string
+		init       func(txn *memdb.Txn)
+		givenEmail string
+		givenID    string
+		want       User
+		wantErr    error
+	}{
+		{
+			name: "should delete the user with the given email",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+			}),
+			givenEmail: "eza@pod.ru",
+			want:       User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", DeletedAt: deletedAt, UpdatedAt: deletedAt, Version: 1},
+		},
+		{
+			name: "should delete the user with the given id",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+			}),
+			givenID: "c7dca0a",
+			want:    User{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb", DeletedAt: deletedAt, UpdatedAt: deletedAt, Version: 1},
+		},
+		{
+			name: "should return an error when the user is already deleted",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru", DeletedAt: deletedAt},
+			}),
+			givenEmail: "eza@pod.ru",
+			wantErr:    EmailNotFound,
+		},
+		{
+			name: "should return an error when the id does not match the email",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+			}),
+			givenEmail: "eza@pod.ru",
+			givenID:    "c7dca0a",
+			wantErr:    IDDoesNotMatchEmail,
+		},
+		{
+			name:       "should return an error when no user has this email",
+			init:       fillDBWith(nil),
+			givenEmail: "eza@pod.ru",
+			wantErr:    EmailNotFound,
+		},
+		{
+			name:    "should return an error when no user has this id",
+			init:    fillDBWith(nil),
+			givenID: "ba3d530",
+			wantErr: IDNotFound,
+		},
+		{
+			name:    "should return an error when neither the email nor the id is given",
+			init:    fillDBWith(nil),
+			wantErr: DeleteKeyEmpty,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+
+			tt.init(txn)
+
+			got, gotErr := svc.Delete(txn, tt.givenEmail, tt.givenID, "")
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+
+				_, err := svc.GetByEmail(txn, tt.want.Email, false)
+				td.Cmp(t, err, EmailNotFound)
+
+				// The user is only soft-deleted.
+				inDB, err := svc.GetByEmail(txn, tt.want.Email, true)
+				if td.CmpNoError(t, err) {
+					td.Cmp(t, inDB, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	db := NewDBOrPanic()
+	svc := UserSvc{Clock: func() time.Time { return writtenAt }}
+
+	tests := []struct {
+		name          string
+		init          func(txn *memdb.Txn)
+		givenOldEmail string
+		givenNewEmail string
+		want          User
+		wantErr       error
+	}{
+		{
+			name: "should re-key the user and keep its id",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			givenOldEmail: "eza@pod.ru",
+			givenNewEmail: "elnora@pod.ru",
+			want:          User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "elnora@pod.ru", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should return an error when the new email is already used",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+			}),
+			givenOldEmail: "eza@pod.ru",
+			givenNewEmail: "le@rec.gb",
+			wantErr:       EmailAlreadyExists,
+		},
+		{
+			name: "should allow changing the case of the email",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			givenOldEmail: "eza@pod.ru",
+			givenNewEmail: "Eza@Pod.ru",
+			want:          User{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "Eza@Pod.ru", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should change the case even when the old email is given with the new case",
+			init: fillDBWith([]User{
+				{FirstName: "Brianna", LastName: "Shelton", Age: 23, ID: "e1f2a3b", Email: "Brianna@Email.org"},
+			}),
+			givenOldEmail: "brianna@email.org",
+			givenNewEmail: "brianna@email.org",
+			want:          User{FirstName: "Brianna", LastName: "Shelton", Age: 23, ID: "e1f2a3b", Email: "brianna@email.org", UpdatedAt: writtenAt, Version: 1},
+		},
+		{
+			name: "should leave the user untouched when the email does not change",
+			init: fillDBWith([]User{
+				{FirstName: "Brianna", LastName: "Shelton", Age: 23, ID: "e1f2a3b", Email: "Brianna@Email.org"},
+			}),
+			givenOldEmail: "brianna@email.org",
+			givenNewEmail: "Brianna@Email.org",
+			want:          User{FirstName: "Brianna", LastName: "Shelton", Age: 23, ID: "e1f2a3b", Email: "Brianna@Email.org"},
+		},
+		{
+			name: "should return an error when the new email is used with another case",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+			}),
+			givenOldEmail: "eza@pod.ru",
+			givenNewEmail: "LE@rec.gb",
+			wantErr:       EmailAlreadyExists,
+		},
+		{
+			name:          "should return an error when the new email is malformed",
+			init:          fillDBWith(nil),
+			givenOldEmail: "eza@pod.ru",
+			givenNewEmail: "elnora@",
+			wantErr:       EmailInvalid,
+		},
+		{
+			name:          "should return an error when no user has the old email",
+			init:          fillDBWith(nil),
+			givenOldEmail: "eza@pod.ru",
+			givenNewEmail: "elnora@pod.ru",
+			wantErr:       EmailNotFound,
+		},
+		{
+			name:          "should return an error when the new email is empty",
+			init:          fillDBWith(nil),
+			givenOldEmail: "eza@pod.ru",
+			wantErr:       EmailEmpty,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+
+			tt.init(txn)
+
+			got, gotErr := svc.ChangeEmail(txn, tt.givenOldEmail, tt.givenNewEmail, "")
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+
+				// The old email still finds the user when only its case
+				// was changed.
+				if !strings.EqualFold(tt.givenOldEmail, tt.givenNewEmail) {
+					_, err := svc.GetByEmail(txn, tt.givenOldEmail, false)
+					td.Cmp(t, err, EmailNotFound)
+				}
+
+				inDB, err := svc.GetByEmail(txn, tt.givenNewEmail, false)
+				if td.CmpNoError(t, err) {
+					td.Cmp(t, inDB, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db := NewDBOrPanic()
+
+	tests := []struct {
+		name    string
+		init    func(txn *memdb.Txn)
+		getID   string
+		want    User
+		wantErr error
+	}{
+		{
+			name: "should return an error when no user has this id",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+			}),
+			getID:   "c7dca0a",
+			wantErr: IDNotFound,
+		},
+		{
+			name: "should return Wayne when 'c7dca0a' is given",
+			init: fillDBWith([]User{
+				{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+				{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "wayne.keller@rec.gb"},
+			}),
+			getID: "c7dca0a",
+			want:  User{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "wayne.keller@rec.gb"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := db.Txn(true)
+			defer txn.Abort()
+
+			tt.init(txn)
+
+			got, gotErr := UserSvc{}.GetByID(txn, tt.getID)
+			if tt.wantErr != nil {
+				td.Cmp(t, gotErr, tt.wantErr)
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamList(t *testing.T) {
+	db := NewDBOrPanic()
+	users := []User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+		{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+	}
+
+	t.Run("should call fn for each user in email order", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		var got []User
+		err := UserSvc{}.StreamList(txn, false, TimeRange{}, nil, func(u User) error {
+			got = append(got, u)
+			return nil
+		})
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, users)
+	})
+
+	t.Run("should filter the users the same way as List", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith([]User{
+			{ID: "ba3d530", Email: "eza@pod.ru", Labels: map[string]string{"team": "payments"}, CreatedAt: writtenAt},
+			{ID: "c7dca0a", Email: "le@rec.gb", Labels: map[string]string{"team": "payments"}, DeletedAt: deletedAt},
+			{ID: "a4bcd38", Email: "zikuwcus@awobik.kr", CreatedAt: writtenAt.Add(time.Hour)},
+		})(txn)
+		selector, err := ParseSelector("team=payments")
+		td.CmpNoError(t, err)
+
+		var got []string
+		collect := func(u User) error {
+			got = append(got, u.Email)
+			return nil
+		}
+		err = UserSvc{}.StreamList(txn, false, TimeRange{}, selector, collect)
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []string{"eza@pod.ru"})
+
+		got = nil
+		err = UserSvc{}.StreamList(txn, true, TimeRange{}, selector, collect)
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []string{"eza@pod.ru", "le@rec.gb"})
+
+		got = nil
+		err = UserSvc{}.StreamList(txn, false, TimeRange{After: writtenAt}, nil, collect)
+		td.CmpNoError(t, err)
+		td.Cmp(t, got, []string{"zikuwcus@awobik.kr"})
+
+		err = UserSvc{}.StreamList(txn, false, TimeRange{After: writtenAt, Before: writtenAt}, nil, collect)
+		td.Cmp(t, err, TimeRangeInvalid)
+	})
+
+	t.Run("should stop at the first error returned by fn", func(t *testing.T) {
+		txn := db.Txn(true)
+		defer txn.Abort()
+		fillDBWith(users)(txn)
+
+		var got []User
+		err := UserSvc{}.StreamList(txn, false, TimeRange{}, nil, func(u User) error {
+			got = append(got, u)
+			return fmt.Errorf("client went away")
+		})
+		td.Cmp(t, err, fmt.Errorf("client went away"))
+		td.Cmp(t, got, users[:1])
+	})
+}
+
+func TestStreamSearchAge(t *testing.T) {
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	defer txn.Abort()
+	fillDBWith([]User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+		{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+	})(txn)
+
+	var got []User
+	err := UserSvc{}.StreamSearchAge(txn, 30, 50, func(u User) error {
+		got = append(got, u)
+		return nil
+	})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User{
+		{FirstName: "Flora", LastName: "Hale", Age: 38, ID: "a4bcd38", Email: "zikuwcus@awobik.kr"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+	})
+
+	err = UserSvc{}.StreamSearchAge(txn, 50, 30, func(u User) error { return nil })
+	td.Cmp(t, err, AgeFromIsGreaterThanAgeTo)
+}
+
+func TestStreamSearchName(t *testing.T) {
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	defer txn.Abort()
+	fillDBWith([]User{
+		{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"},
+		{FirstName: "Wayne", LastName: "Keller", Age: 42, ID: "c7dca0a", Email: "le@rec.gb"},
+	})(txn)
+
+	var got []User
+	err := UserSvc{}.StreamSearchName(txn, "nor", func(u User) error {
+		got = append(got, u)
+		return nil
+	})
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, []User{{FirstName: "Elnora", LastName: "Morales", Age: 21, ID: "ba3d530", Email: "eza@pod.ru"}})
+
+	err = UserSvc{}.StreamSearchName(txn, "", func(u User) error { return nil })
+	td.Cmp(t, err, NameQueryEmpty)
+}
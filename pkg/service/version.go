@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	VersionMismatch = errors.New("the user was modified in the meantime")
+	EtagInvalid     = errors.New("invalid etag")
+)
+
+// Etag returns the version of the user followed by its creation time as an
+// opaque string, e.g. "3-kb2x8n4w00". The creation time tells a user apart
+// from a user created later with the same email once the first one is
+// purged. The users that were never written through the service, such as
+// the ones inserted directly in the database, have no etag.
+func (u User) Etag() string {
+	if u.Version == 0 {
+		return ""
+	}
+
+	var created int64
+	if !u.CreatedAt.IsZero() {
+		created = u.CreatedAt.UnixNano()
+	}
+
+	return strconv.FormatInt(u.Version, 10) + "-" + strconv.FormatInt(created, 36)
+}
+
+// checkEtag is used by the writes to make sure that the user was not
+// modified since the caller read it. The empty etag skips the check.
+//
+// Possible errors: EtagInvalid, VersionMismatch.
+func checkEtag(u *User, etag string) error {
+	if etag == "" {
+		return nil
+	}
+
+	i := strings.Index(etag, "-")
+	if i < 0 {
+		return EtagInvalid
+	}
+	version, err := strconv.ParseInt(etag[:i], 10, 64)
+	if err != nil || version <= 0 {
+		return EtagInvalid
+	}
+	if _, err := strconv.ParseInt(etag[i+1:], 36, 64); err != nil {
+		return EtagInvalid
+	}
+
+	if etag != u.Etag() {
+		return VersionMismatch
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestUser_Etag(t *testing.T) {
+	td.Cmp(t, User{Version: 3}.Etag(), "3-0")
+	td.Cmp(t, User{Version: 3, CreatedAt: time.Unix(0, 36)}.Etag(), "3-10")
+	td.Cmp(t, User{}.Etag(), "")
+}
+
+func Test_checkEtag(t *testing.T) {
+	user := &User{Version: 3, CreatedAt: writtenAt}
+
+	tests := []struct {
+		given   string
+		wantErr error
+	}{
+		{given: user.Etag()},
+		{given: ""},
+		{given: User{Version: 2, CreatedAt: writtenAt}.Etag(), wantErr: VersionMismatch},
+		{given: User{Version: 3, CreatedAt: deletedAt}.Etag(), wantErr: VersionMismatch},
+		{given: "3", wantErr: EtagInvalid},
+		{given: "0-0", wantErr: EtagInvalid},
+		{given: "-1-0", wantErr: EtagInvalid},
+		{given: "3-?", wantErr: EtagInvalid},
+		{given: `W/"3"`, wantErr: EtagInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			td.Cmp(t, checkEtag(user, tt.given), tt.wantErr)
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	clock := writtenAt
+	svc := UserSvc{Clock: func() time.Time { return clock }}
+
+	db := NewDBOrPanic()
+	txn := db.Txn(true)
+	defer txn.Abort()
+
+	td.CmpNoError(t, svc.Create(txn, User{ID: "ba3d530", Email: "eza@pod.ru", Age: 21}))
+
+	// Each write increments the version and only happens when the given
+	// etag is the current one.
+	writes := []struct {
+		name  string
+		write func(txn *memdb.Txn, etag string) (User, error)
+	}{
+		{name: "Update", write: func(txn *memdb.Txn, etag string) (User, error) {
+			return svc.Update(txn, "eza@pod.ru", []string{"age"}, User{Age: 22}, etag)
+		}},
+		{name: "ChangeEmail", write: func(txn *memdb.Txn, etag string) (User, error) {
+			return svc.ChangeEmail(txn, "eza@pod.ru", "Eza@pod.ru", etag)
+		}},
+		{name: "Delete", write: func(txn *memdb.Txn, etag string) (User, error) {
+			return svc.Delete(txn, "eza@pod.ru", "", etag)
+		}},
+		{name: "Restore", write: func(txn *memdb.Txn, etag string) (User, error) {
+			return svc.Restore(txn, "eza@pod.ru", etag)
+		}},
+	}
+	for i, w := range writes {
+		version := int64(i + 1)
+		t.Run(w.name, func(t *testing.T) {
+			_, err := w.write(txn, User{Version: version + 1, CreatedAt: writtenAt}.Etag())
+			td.Cmp(t, err, VersionMismatch)
+
+			got, err := w.write(txn, User{Version: version, CreatedAt: writtenAt}.Etag())
+			td.CmpNoError(t, err)
+			td.Cmp(t, got.Version, version+1)
+		})
+	}
+
+	t.Run("Purge", func(t *testing.T) {
+		deleted, err := svc.Delete(txn, "eza@pod.ru", "", "")
+		td.CmpNoError(t, err)
+
+		_, err = svc.Purge(txn, "eza@pod.ru", User{Version: 5, CreatedAt: writtenAt}.Etag())
+		td.Cmp(t, err, VersionMismatch)
+		_, err = svc.Purge(txn, "eza@pod.ru", deleted.Etag())
+		td.CmpNoError(t, err)
+	})
+
+	t.Run("the etag of a purged user should not match a new user with the same email", func(t *testing.T) {
+		td.CmpNoError(t, svc.Create(txn, User{Email: "old@pod.ru"}))
+		old, err := svc.GetByEmail(txn, "old@pod.ru", false)
+		td.CmpNoError(t, err)
+		_, err = svc.Delete(txn, "old@pod.ru", "", "")
+		td.CmpNoError(t, err)
+		_, err = svc.Purge(txn, "old@pod.ru", "")
+		td.CmpNoError(t, err)
+
+		clock = writtenAt.Add(time.Hour)
+		td.CmpNoError(t, svc.Create(txn, User{Email: "old@pod.ru"}))
+		recreated, err := svc.GetByEmail(txn, "old@pod.ru", false)
+		td.CmpNoError(t, err)
+		td.Cmp(t, recreated.Version, old.Version)
+
+		_, err = svc.Update(txn, "old@pod.ru", []string{"age"}, User{Age: 30}, old.Etag())
+		td.Cmp(t, err, VersionMismatch)
+	})
+}
@@ -0,0 +1,110 @@
+// Package userfields parses and formats the free-text forms of the user
+// fields, such as addresses and labels. It only depends on the standard
+// library so that users-cli can use it without linking the server.
+package userfields
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var AddressInvalid = errors.New("invalid address")
+
+// Address is a postal address. All the fields are optional.
+type Address struct {
+	Street     string `json:"street,omitempty"`     // "255 Cortelyou Road"
+	City       string `json:"city,omitempty"`       // "Volta"
+	Region     string `json:"region,omitempty"`     // "Indiana"
+	PostalCode string `json:"postalCode,omitempty"` // "1608"
+	Country    string `json:"country,omitempty"`
+}
+
+// String returns the non-empty parts of the address separated by commas,
+// e.g. "255 Cortelyou Road, Volta, Indiana, 1608". It is the inverse of
+// ParseAddress.
+func (a Address) String() string {
+	var parts []string
+	for _, part := range []string{a.Street, a.City, a.Region, a.PostalCode, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseAddress parses a free-text address of the form
+//
+//	street, city, region, postal code, country
+//
+// where only the street is mandatory. The postal code is the first part
+// after the city that contains a digit and the country is what comes after
+// it; for example, "12 rue de l'Église, Besançon, 25000, France" has no
+// region. The empty string gives the zero Address.
+//
+// Possible errors: AddressInvalid.
+func ParseAddress(s string) (Address, error) {
+	if strings.TrimSpace(s) == "" {
+		return Address{}, nil
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) > 5 {
+		return Address{}, fmt.Errorf("%w: '%s' has more than 5 parts", AddressInvalid, s)
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if parts[i] == "" {
+			return Address{}, fmt.Errorf("%w: '%s' has an empty part", AddressInvalid, s)
+		}
+	}
+
+	addr := Address{Street: parts[0]}
+	if len(parts) == 1 {
+		return addr, nil
+	}
+
+	addr.City = parts[1]
+	for _, part := range parts[2:] {
+		switch {
+		case addr.Country != "":
+			return Address{}, fmt.Errorf("%w: nothing can come after the country in '%s'", AddressInvalid, s)
+		case addr.PostalCode == "" && strings.IndexFunc(part, unicode.IsDigit) >= 0:
+			addr.PostalCode = part
+		case addr.Region == "" && addr.PostalCode == "":
+			addr.Region = part
+		default:
+			addr.Country = part
+		}
+	}
+
+	return addr, nil
+}
+
+// UnmarshalJSON accepts both the structured form of the address and the
+// free-text form, e.g. "255 Cortelyou Road, Volta, Indiana, 1608", which
+// is parsed using ParseAddress. This is what lets the sample users keep
+// their free-text addresses.
+func (a *Address) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		addr, err := ParseAddress(s)
+		if err != nil {
+			return err
+		}
+		*a = addr
+		return nil
+	}
+
+	// Since the local type has no UnmarshalJSON method, this does not
+	// recurse.
+	type address Address
+	var addr address
+	if err := json.Unmarshal(data, &addr); err != nil {
+		return err
+	}
+	*a = Address(addr)
+	return nil
+}
@@ -0,0 +1,67 @@
+package userfields
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		given   string
+		want    Address
+		wantErr error
+	}{
+		{given: "255 Cortelyou Road, Volta, Indiana, 1608", want: Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}},
+		{given: "291 Boardwalk , Chloride, North Carolina, 8401", want: Address{Street: "291 Boardwalk", City: "Chloride", Region: "North Carolina", PostalCode: "8401"}},
+		{given: "1 Main St, Ottawa, Ontario, K1A 0B1, Canada", want: Address{Street: "1 Main St", City: "Ottawa", Region: "Ontario", PostalCode: "K1A 0B1", Country: "Canada"}},
+		{given: "12 rue de l'Église, Besançon, 25000, France", want: Address{Street: "12 rue de l'Église", City: "Besançon", PostalCode: "25000", Country: "France"}},
+		{given: "1930 Movun Point, Svalbard & Jan Mayen", want: Address{Street: "1930 Movun Point", City: "Svalbard & Jan Mayen"}},
+		{given: "Toulouse", want: Address{Street: "Toulouse"}},
+		{given: "", want: Address{}},
+		{given: "a, b, c, d, e, f", wantErr: AddressInvalid},
+		{given: "255 Cortelyou Road, , Indiana", wantErr: AddressInvalid},
+		{given: "a, b, 1, c, d", wantErr: AddressInvalid},
+		{given: "a, b, c, d, 1", wantErr: AddressInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			got, gotErr := ParseAddress(tt.given)
+			if tt.wantErr != nil {
+				td.CmpTrue(t, errors.Is(gotErr, tt.wantErr))
+				return
+			}
+			if td.CmpNoError(t, gotErr) {
+				td.Cmp(t, got, tt.want)
+
+				back, err := ParseAddress(got.String())
+				td.CmpNoError(t, err)
+				td.Cmp(t, back, got, "String should be the inverse of ParseAddress")
+			}
+		})
+	}
+}
+
+func TestAddress_String(t *testing.T) {
+	td.Cmp(t, Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"}.String(), "255 Cortelyou Road, Volta, Indiana, 1608")
+	td.Cmp(t, Address{Street: "12 rue de l'Église", City: "Besançon", Country: "France"}.String(), "12 rue de l'Église, Besançon, France")
+	td.Cmp(t, Address{}.String(), "")
+}
+
+func TestAddress_UnmarshalJSON(t *testing.T) {
+	var u struct {
+		Address Address `json:"address"`
+	}
+	err := json.Unmarshal([]byte(`{"address": "255 Cortelyou Road, Volta, Indiana, 1608"}`), &u)
+	td.CmpNoError(t, err)
+	td.Cmp(t, u.Address, Address{Street: "255 Cortelyou Road", City: "Volta", Region: "Indiana", PostalCode: "1608"})
+
+	err = json.Unmarshal([]byte(`{"address": {"street": "255 Cortelyou Road", "city": "Volta", "postalCode": "1608"}}`), &u)
+	td.CmpNoError(t, err)
+	td.Cmp(t, u.Address, Address{Street: "255 Cortelyou Road", City: "Volta", PostalCode: "1608"})
+
+	err = json.Unmarshal([]byte(`{"address": "a, b, c, d, e, f"}`), &u)
+	td.CmpTrue(t, errors.Is(err, AddressInvalid))
+}
@@ -0,0 +1,90 @@
+package userfields
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var LabelInvalid = errors.New("invalid label")
+
+// The labels follow the syntax of the Kubernetes labels: the keys and the
+// values are made of at most 63 alphanumerical characters, '-', '_' and
+// '.', and must start and end with an alphanumerical character. The keys
+// can also have a prefix separated by a '/', e.g. "example.com/team". The
+// values can be empty.
+var (
+	labelName   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`)
+	labelPrefix = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?$`)
+)
+
+// ValidateLabels makes sure that the keys and the values of the labels
+// have the right syntax.
+//
+// Possible errors: LabelInvalid.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		err := ValidateLabelKey(key)
+		if err != nil {
+			return err
+		}
+		if ValidateLabelValue(value) != nil {
+			return fmt.Errorf("%w: the value '%s' of the label %s must be at most 63 alphanumerical characters, '-', '_' or '.'", LabelInvalid, value, key)
+		}
+	}
+	return nil
+}
+
+// ValidateLabelKey makes sure that the key, with its optional prefix, has
+// the right syntax.
+//
+// Possible errors: LabelInvalid.
+func ValidateLabelKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		if !labelPrefix.MatchString(key[:i]) {
+			return fmt.Errorf("%w: the prefix of the key '%s' must be a DNS subdomain", LabelInvalid, key)
+		}
+		name = key[i+1:]
+	}
+	if !labelName.MatchString(name) {
+		return fmt.Errorf("%w: the key '%s' must be at most 63 alphanumerical characters, '-', '_' or '.'", LabelInvalid, key)
+	}
+	return nil
+}
+
+// ValidateLabelValue makes sure that the value has the right syntax. The
+// empty value is valid.
+//
+// Possible errors: LabelInvalid.
+func ValidateLabelValue(value string) error {
+	if value != "" && !labelName.MatchString(value) {
+		return fmt.Errorf("%w: the value '%s' must be at most 63 alphanumerical characters, '-', '_' or '.'", LabelInvalid, value)
+	}
+	return nil
+}
+
+// ParseLabels parses labels of the form "team=payments,plan=pro".
+//
+// Possible errors: LabelInvalid.
+func ParseLabels(s string) (map[string]string, error) {
+	labels := make(map[string]string)
+	if s == "" {
+		return labels, nil
+	}
+
+	for _, label := range strings.Split(s, ",") {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: '%s', expected the form 'key=value'", LabelInvalid, label)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if _, ok := labels[key]; ok {
+			return nil, fmt.Errorf("%w: the key '%s' is given twice", LabelInvalid, key)
+		}
+		labels[key] = value
+	}
+
+	return labels, ValidateLabels(labels)
+}
@@ -0,0 +1,23 @@
+package userfields
+
+import (
+	"errors"
+	"testing"
+
+	td "github.com/maxatome/go-testdeep/td"
+)
+
+func TestParseLabels(t *testing.T) {
+	got, err := ParseLabels("team=payments, plan=pro,empty=")
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, map[string]string{"team": "payments", "plan": "pro", "empty": ""})
+
+	got, err = ParseLabels("")
+	td.CmpNoError(t, err)
+	td.Cmp(t, got, map[string]string{})
+
+	for _, given := range []string{"team", "team=a,team=b", "-team=a", "team=a/b", "Example.com/team=a"} {
+		_, err = ParseLabels(given)
+		td.CmpTrue(t, errors.Is(err, LabelInvalid), "%s: got %v", given, err)
+	}
+}
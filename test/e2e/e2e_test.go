@@ -12,6 +12,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -44,6 +45,61 @@ func Test_CLI(t *testing.T) {
 
 			assert.Equal(t, 0, cli.ProcessState.ExitCode())
 		})
+
+		t.Run("should only return the first page when --page-size is given", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--page-size=10")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			output := contents(cli.Output)
+			assert.Equal(t, 10, strings.Count(output, "years old"))
+			assert.Contains(t, output, "Acevedo Quinn <acevedo.quinn@email.us>")
+			assert.Contains(t, output, "more users are available, use --page-token=")
+		})
+
+		t.Run("should return all 30 lines when --all is given", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--page-size=7", "--all")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			output := contents(cli.Output)
+			assert.Equal(t, 30, strings.Count(output, "\n"))
+			assert.Contains(t, output, "Wilkerson Mosley <wilkerson.mosley@email.biz> (48 years old, address: 734 Kosciusko Street, Marbury, Connecticut, 3037)")
+		})
+		t.Run("should print all 30 lines as they arrive when --stream is given", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--stream")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			output := contents(cli.Output)
+			assert.Equal(t, 30, strings.Count(output, "\n"))
+			assert.Contains(t, output, "Wilkerson Mosley <wilkerson.mosley@email.biz> (48 years old, address: 734 Kosciusko Street, Marbury, Connecticut, 3037)")
+		})
+		t.Run("should apply the filters when --stream is given", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "rice.pierce@email.com", "--label=team=payments")).Wait()
+			require.Equal(t, 0, cli.ProcessState.ExitCode(), contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--stream", "--selector=team=payments")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401) [labels: team=payments]\n", contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--stream", "--sort=age")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "--sort cannot be used with --stream")
+		})
 	})
 
 	t.Run("users-cli create", func(t *testing.T) {
@@ -87,6 +143,81 @@ func Test_CLI(t *testing.T) {
 			assert.Equal(t, 1, cli.ProcessState.ExitCode())
 			assert.Contains(t, contents(cli.Output), "email already exists")
 		})
+
+		t.Run("should exit with 1 when creating with an existing email written with another case", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "create", "--email=Wilkerson.Mosley@email.biz")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "email already exists")
+		})
+
+		t.Run("should exit with 1 when creating with a malformed email", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "create", "--email=foo.bar.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the email 'foo.bar.com' is invalid")
+		})
+	})
+
+	t.Run("users-cli import", func(t *testing.T) {
+		t.Run("should create all the users of the file", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cmd := exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "import", "--batch-size=1", "-")
+			cmd.Stdin = strings.NewReader(`[
+				{"email": "brianna.shelton@email.org", "firstName": "Brianna", "lastName": "Shelton", "age": 23, "address": "255 Cortelyou Road, Volta, Indiana, 1608"},
+				{"email": "rice.pierce@email.com", "firstName": "Rice", "lastName": "Pierce"}
+			]`)
+			cli := startWith(t, cmd).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "2 users created")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "brianna.shelton@email.org")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "Brianna Shelton <brianna.shelton@email.org> (23 years old, address: 255 Cortelyou Road, Volta, Indiana, 1608)")
+		})
+
+		t.Run("should report the rejected users and exit with 1", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cmd := exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "import", "-")
+			cmd.Stdin = strings.NewReader(`[
+				{"email": "jane.doe@email.org", "firstName": "Jane", "lastName": "Doe"},
+				{"email": "wilkerson.mosley@email.biz"},
+				{"email": "foo.bar.com"}
+			]`)
+			cli := startWith(t, cmd).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			output := contents(cli.Output)
+			assert.Contains(t, output, "user #2 (wilkerson.mosley@email.biz): email already exists")
+			assert.Contains(t, output, "user #3 (foo.bar.com): the email 'foo.bar.com' is invalid")
+			assert.Contains(t, output, "1 users created, 2 rejected")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "jane.doe@email.org")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+		})
+
+		t.Run("should exit with 1 when the file is not a JSON array", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cmd := exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "import", "-")
+			cmd.Stdin = strings.NewReader(`{"email": "brianna.shelton@email.org"}`)
+			cli := startWith(t, cmd).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the file must contain a JSON array")
+		})
 	})
 
 	t.Run("users-cli get", func(t *testing.T) {
@@ -98,7 +229,7 @@ func Test_CLI(t *testing.T) {
 
 			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "rice.pierce@email.com")).Wait()
 			assert.Equal(t, 0, cli.ProcessState.ExitCode())
-			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk , Chloride, North Carolina, 8401)\n", contents(cli.Output))
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli.Output))
 		})
 
 		t.Run("should exit with 1 when the email is not found", func(t *testing.T) {
@@ -110,6 +241,498 @@ func Test_CLI(t *testing.T) {
 			assert.Equal(t, 1, cli.ProcessState.ExitCode())
 			assert.Contains(t, contents(cli.Output), "the email impossible.name@email.com cannot be found")
 		})
+
+		t.Run("should print the user associated with a given id", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--id=5cfdf218e5f9edbd4bba3faf")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli.Output))
+		})
+
+		t.Run("should print the user associated with a given id, even when it contains an '@'", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cmd := exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "import", "-")
+			cmd.Stdin = strings.NewReader(`[{"id": "jane@hr", "email": "jane.doe@email.org", "firstName": "Jane", "lastName": "Doe"}]`)
+			cli := startWith(t, cmd).Wait()
+			require.Equal(t, 0, cli.ProcessState.ExitCode(), contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--id=jane@hr")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Regexp(t, `^Jane Doe <jane.doe@email.org>`, contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when the id is not found", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--id=5cfdf2180000000000000000")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the id 5cfdf2180000000000000000 cannot be found")
+		})
+
+		t.Run("should print the user associated with a given phone, whatever its formatting", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--phone=899.428.2988")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when the phone is not found", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--phone=+33 6 12 34 56 78")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the phone +33 6 12 34 56 78 cannot be found")
+		})
+		t.Run("should exit with 1 when --include-deleted is given with --id", func(t *testing.T) {
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", "127.0.0.1:"+freePort(), "get", "--include-deleted", "--id=5cfdf218e5f9edbd4bba3faf")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "--include-deleted only works with an email")
+		})
+	})
+
+	t.Run("users-cli update", func(t *testing.T) {
+		t.Run("should only update the given fields", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "rice.pierce@email.com", "--age=47")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (47 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli.Output))
+
+			cli2 := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli2.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (47 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli2.Output))
+		})
+
+		t.Run("should exit with 1 when the email is not found", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "impossible.name@email.com", "--age=47")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the email impossible.name@email.com cannot be found")
+		})
+
+		t.Run("should refuse to overwrite a user that was modified since its etag was read", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--etag", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			etag := regexp.MustCompile(`\[etag: (1-[0-9a-z]+)\]\n$`).FindStringSubmatch(contents(cli.Output))
+			require.Len(t, etag, 2, contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "rice.pierce@email.com", "--age=47", "--if-match="+etag[1])).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "rice.pierce@email.com", "--age=48", "--if-match="+etag[1])).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the user rice.pierce@email.com was modified since the etag "+etag[1]+" was read; someone else changed this user in the meantime, run 'users-cli get --etag rice.pierce@email.com'")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--etag", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Regexp(t, `^Rice Pierce <rice.pierce@email.com> \(47 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401\) \[etag: 2-[0-9a-z]+\]\n$`, contents(cli.Output))
+		})
+	})
+
+	t.Run("users-cli list --created-after", func(t *testing.T) {
+		t.Run("should only list the users created in the range", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			// The samples are created when the server starts.
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--created-after=2020-01-01")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, 30, strings.Count(contents(cli.Output), "\n"))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--created-before=2020-01-01T00:00:00Z")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "", contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when the time is malformed", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--created-after=yesterday")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "--created-after: 'yesterday' is neither a date")
+		})
+	})
+
+	t.Run("users-cli list --selector", func(t *testing.T) {
+		t.Run("should only list the users whose labels match", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "rice.pierce@email.com", "--label=team=payments", "--label=plan=pro")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "[labels: plan=pro,team=payments]")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "create", "--email=mael.valais@gmail.com", "--label=team=payments")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--selector=team=payments,plan notin (pro)")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			output := contents(cli.Output)
+			assert.Equal(t, 1, strings.Count(output, "\n"))
+			assert.Contains(t, output, "<mael.valais@gmail.com>")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "rice.pierce@email.com", "--label=plan-")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--agefrom=40", "-l", "team")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401) [labels: team=payments]\n", contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when the selector is malformed", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list", "--selector=plan in pro")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "invalid label selector")
+		})
+	})
+
+	t.Run("users-cli delete", func(t *testing.T) {
+		t.Run("should delete the user without prompting when stdout is not a tty", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "delete", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli2 := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "list")).Wait()
+			assert.Equal(t, 0, cli2.ProcessState.ExitCode())
+			output := contents(cli2.Output)
+			assert.Equal(t, 29, strings.Count(output, "\n"))
+			assert.NotContains(t, output, "rice.pierce@email.com")
+		})
+
+		t.Run("should exit with 1 when the email is not found", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "delete", "--yes", "impossible.name@email.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the email impossible.name@email.com cannot be found")
+		})
+	})
+
+	t.Run("users-cli restore", func(t *testing.T) {
+		t.Run("should bring back a deleted user", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "delete", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the email rice.pierce@email.com cannot be found")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "--include-deleted", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401) [deleted]\n", contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "restore", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when the user is not deleted", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "restore", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the user rice.pierce@email.com is not deleted")
+		})
+	})
+
+	t.Run("users-cli purge", func(t *testing.T) {
+		t.Run("should free the email of a deleted user", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "delete", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "create", "--email=rice.pierce@email.com", "--firstname=Rice", "--lastname=Pierce")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "email already exists")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "purge", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "create", "--email=rice.pierce@email.com", "--firstname=Rice", "--lastname=Pierce")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+		})
+
+		t.Run("should exit with 1 when the user is not deleted", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "purge", "--yes", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the user rice.pierce@email.com must be deleted before being purged")
+		})
+	})
+
+	t.Run("users-cli change-email", func(t *testing.T) {
+		t.Run("should change the email of the user", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "change-email", "rice.pierce@email.com", "rice@pierce.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Rice Pierce <rice@pierce.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli.Output))
+
+			cli2 := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "get", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 1, cli2.ProcessState.ExitCode())
+		})
+
+		t.Run("should exit with 1 when the new email is already used", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "change-email", "rice.pierce@email.com", "wilkerson.mosley@email.biz")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "email already exists")
+		})
+	})
+
+	t.Run("users-cli stats", func(t *testing.T) {
+		t.Run("should count the users by age", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "stats", "--age-buckets=30,40")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			output := contents(cli.Output)
+			assert.Regexp(t, `users +30\n`, output)
+			assert.Regexp(t, `0-29 +8\n`, output)
+			assert.Regexp(t, `30-39 +5\n`, output)
+			assert.Regexp(t, `40\+ +17\n`, output)
+			assert.Regexp(t, `email\.biz +5\n`, output)
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "stats", "--output=json")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), `"total": 30,`)
+		})
+
+		t.Run("should exit with 1 when the age buckets are not increasing", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "stats", "--age-buckets=40,30")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the age bucket bounds [40 30] must be positive and increasing")
+		})
+	})
+
+	t.Run("users-cli group", func(t *testing.T) {
+		t.Run("should add and list the members of a group", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "create", "payments", "--description=The payments team")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "add", "payments", "wilkerson.mosley@email.biz", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "members", "payments")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, heredoc.Doc(`
+				Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)
+				Wilkerson Mosley <wilkerson.mosley@email.biz> (48 years old, address: 734 Kosciusko Street, Marbury, Connecticut, 3037)
+			`), contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "list", "--member=rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "payments (2 members): The payments team\n", contents(cli.Output))
+		})
+
+		t.Run("should hide deleted users from their groups", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "create", "payments")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "add", "payments", "wilkerson.mosley@email.biz", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "delete", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "list")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "payments (1 members)\n", contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when some users cannot be added", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "create", "payments")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "add", "payments", "rice.pierce@email.com", "nobody@email.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			output := contents(cli.Output)
+			assert.Contains(t, output, "nobody@email.com: the email nobody@email.com cannot be found")
+			assert.Contains(t, output, "1 users added, 1 rejected")
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "group", "add", "nobody", "rice.pierce@email.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the group nobody cannot be found")
+		})
+	})
+
+	t.Run("users-cli org", func(t *testing.T) {
+		t.Run("should print the users that report to a user as a tree", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			for _, args := range [][]string{
+				{"rice.pierce@email.com", "--manager=brianna.shelton@email.org"},
+				{"wilkerson.mosley@email.biz", "--manager=brianna.shelton@email.org"},
+				{"alford.cole@email.net", "--manager=rice.pierce@email.com"},
+			} {
+				cli := startWith(t, exec.Command(bincli, append([]string{"--color=never", "--cleartext", "--address", addr, "update"}, args...)...)).Wait()
+				require.Equal(t, 0, cli.ProcessState.ExitCode(), contents(cli.Output))
+			}
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "org", "brianna.shelton@email.org")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, heredoc.Doc(`
+				Brianna Shelton <brianna.shelton@email.org>
+				├── Rice Pierce <rice.pierce@email.com>
+				│   └── Alford Cole <alford.cole@email.net>
+				└── Wilkerson Mosley <wilkerson.mosley@email.biz>
+			`), contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "org", "brianna.shelton@email.org", "--depth=1")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, heredoc.Doc(`
+				Brianna Shelton <brianna.shelton@email.org>
+				├── Rice Pierce <rice.pierce@email.com>
+				│   └── ...
+				└── Wilkerson Mosley <wilkerson.mosley@email.biz>
+			`), contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "org", "alford.cole@email.net", "--managers")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			output := contents(cli.Output)
+			assert.Regexp(t, `(?s)^Rice Pierce <rice.pierce@email.com>.*\nBrianna Shelton <brianna.shelton@email.org>`, output)
+			assert.Equal(t, 2, strings.Count(output, "\n"))
+		})
+
+		t.Run("should exit with 1 when the manager would create a cycle", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "rice.pierce@email.com", "--manager=brianna.shelton@email.org")).Wait()
+			require.Equal(t, 0, cli.ProcessState.ExitCode())
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "update", "brianna.shelton@email.org", "--manager=rice.pierce@email.com")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the manager would create a reporting cycle")
+		})
+	})
+
+	t.Run("users-cli set-password and authenticate", func(t *testing.T) {
+		// withStdin runs the cli with the given password written to stdin.
+		withStdin := func(addr, password string, args ...string) *exec.Cmd {
+			cmd := exec.Command(bincli, append([]string{"--color=never", "--cleartext", "--address", addr}, args...)...)
+			cmd.Stdin = strings.NewReader(password + "\n")
+			return cmd
+		}
+
+		t.Run("should print the user when the password matches", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples", "--argon2-memory=64", "--argon2-iterations=1"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, withStdin(addr, "correct horse", "set-password", "brianna.shelton@email.org")).Wait()
+			require.Equal(t, 0, cli.ProcessState.ExitCode(), contents(cli.Output))
+
+			cli = startWith(t, withStdin(addr, "correct horse", "authenticate", "brianna.shelton@email.org")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Regexp(t, `^Brianna Shelton <brianna.shelton@email.org>`, contents(cli.Output))
+
+			cli = startWith(t, withStdin(addr, "short", "set-password", "brianna.shelton@email.org")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "the password is too short")
+		})
+
+		t.Run("should fail the same way for unknown emails and locked accounts", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples", "--argon2-memory=64", "--argon2-iterations=1", "--max-failed-logins=2"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, withStdin(addr, "correct horse", "set-password", "brianna.shelton@email.org")).Wait()
+			require.Equal(t, 0, cli.ProcessState.ExitCode(), contents(cli.Output))
+
+			cli = startWith(t, withStdin(addr, "correct horse", "authenticate", "nobody@email.org")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "invalid email or password")
+
+			cli = startWith(t, withStdin(addr, "wrong horse", "authenticate", "brianna.shelton@email.org")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "invalid email or password")
+
+			cli = startWith(t, withStdin(addr, "wrong horse", "authenticate", "brianna.shelton@email.org")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			wrong := contents(cli.Output)
+			assert.Contains(t, wrong, "invalid email or password")
+
+			// The account is now locked: the right password must fail the
+			// same way as a wrong one.
+			cli = startWith(t, withStdin(addr, "correct horse", "authenticate", "brianna.shelton@email.org")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Equal(t, wrong, contents(cli.Output))
+		})
 	})
 
 	t.Run("users-cli search", func(t *testing.T) {
@@ -120,7 +743,7 @@ func Test_CLI(t *testing.T) {
 
 			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--name", "Pierce")).Wait()
 			assert.Equal(t, 0, cli.ProcessState.ExitCode())
-			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk , Chloride, North Carolina, 8401)\n", contents(cli.Output))
+			assert.Equal(t, "Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)\n", contents(cli.Output))
 		})
 
 		t.Run("should print users that are between two ages", func(t *testing.T) {
@@ -131,13 +754,133 @@ func Test_CLI(t *testing.T) {
 			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--agefrom=46", "--ageto=48")).Wait()
 			assert.Equal(t, 0, cli.ProcessState.ExitCode())
 			assert.Equal(t, heredoc.Doc(`
-				Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk , Chloride, North Carolina, 8401)
+				Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)
+				Angeline Stokes <angeline.stokes@email.biz> (48 years old, address: 526 Java Street, Hailesboro, Pennsylvania, 1648)
+				Pacheco Fitzgerald <pacheco.fitzgerald@email.name> (48 years old, address: 278 McKibben Street, Nicholson, South Dakota, 3793)
+				Wilkerson Mosley <wilkerson.mosley@email.biz> (48 years old, address: 734 Kosciusko Street, Marbury, Connecticut, 3037)
+				`), contents(cli.Output))
+		})
+
+		t.Run("should print users matching both a part of their name and an open-ended age range", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--name=an", "--agefrom=50")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, heredoc.Doc(`
+				Kent Cochran <kent.cochran@email.org> (51 years old, address: 803 Cranberry Street, Inkerman, Marshall Islands, 6929)
+				Brianna Shelton <brianna.shelton@email.org> (52 years old, address: 255 Cortelyou Road, Volta, Indiana, 1608)
+				Santos Slater <santos.slater@email.name> (56 years old, address: 459 Sharon Street, Belleview, Kentucky, 5483)
+				`), contents(cli.Output))
+		})
+
+		t.Run("should print users matching an email domain below an age", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--ageto=23", "--email-domain=email.us")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Acevedo Quinn <acevedo.quinn@email.us> (22 years old, address: 403 Lawn Court, Walland, Federated States Of Micronesia, 8260)\n", contents(cli.Output))
+		})
+
+		t.Run("should print users living in a region or a city", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--region=indiana")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Brianna Shelton <brianna.shelton@email.org> (52 years old, address: 255 Cortelyou Road, Volta, Indiana, 1608)\n", contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--city=Volta", "--region=Texas")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "", contents(cli.Output))
+		})
+
+		t.Run("should print users whose names are close to the query with --match=fuzzy", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--name=Brinna", "--match=fuzzy")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Brianna Shelton <brianna.shelton@email.org> (52 years old, address: 255 Cortelyou Road, Volta, Indiana, 1608) [score: 0.83]\n", contents(cli.Output))
+
+			cli = startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--name=Brinna", "--fuzzy")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Brianna Shelton <brianna.shelton@email.org> (52 years old, address: 255 Cortelyou Road, Volta, Indiana, 1608) [score: 0.83]\n", contents(cli.Output), "--fuzzy is the same as --match=fuzzy")
+		})
+
+		t.Run("should print users whose names sound like the query with --match=phonetic", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--name=Chelton", "--match=phonetic")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, "Brianna Shelton <brianna.shelton@email.org> (52 years old, address: 255 Cortelyou Road, Volta, Indiana, 1608)\n", contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when --max-distance is given without --match=fuzzy", func(t *testing.T) {
+			for _, match := range []string{"--match=phonetic", "--match=substring"} {
+				cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", "127.0.0.1:"+freePort(), "search", "--name=Chelton", match, "--max-distance=1")).Wait()
+				assert.Equal(t, 1, cli.ProcessState.ExitCode())
+				assert.Contains(t, contents(cli.Output), "--max-distance can only be used with --match=fuzzy")
+			}
+		})
+
+		t.Run("should exit with 1 when no criteria is given", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "need at least one of")
+		})
+
+		t.Run("should print users between two ages page by page with --all", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--agefrom=46", "--ageto=48", "--page-size=1", "--all")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, heredoc.Doc(`
+				Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)
 				Angeline Stokes <angeline.stokes@email.biz> (48 years old, address: 526 Java Street, Hailesboro, Pennsylvania, 1648)
 				Pacheco Fitzgerald <pacheco.fitzgerald@email.name> (48 years old, address: 278 McKibben Street, Nicholson, South Dakota, 3793)
 				Wilkerson Mosley <wilkerson.mosley@email.biz> (48 years old, address: 734 Kosciusko Street, Marbury, Connecticut, 3037)
 				`), contents(cli.Output))
 		})
 
+		t.Run("should print users between two ages sorted with --sort", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--agefrom=46", "--ageto=48", "--sort=-age,lastname")).Wait()
+			assert.Equal(t, 0, cli.ProcessState.ExitCode())
+			assert.Equal(t, heredoc.Doc(`
+				Pacheco Fitzgerald <pacheco.fitzgerald@email.name> (48 years old, address: 278 McKibben Street, Nicholson, South Dakota, 3793)
+				Wilkerson Mosley <wilkerson.mosley@email.biz> (48 years old, address: 734 Kosciusko Street, Marbury, Connecticut, 3037)
+				Angeline Stokes <angeline.stokes@email.biz> (48 years old, address: 526 Java Street, Hailesboro, Pennsylvania, 1648)
+				Rice Pierce <rice.pierce@email.com> (46 years old, address: 291 Boardwalk, Chloride, North Carolina, 8401)
+				`), contents(cli.Output))
+		})
+
+		t.Run("should exit with 1 when --sort contains an unknown field", func(t *testing.T) {
+			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
+			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))
+			eventuallyEqual(t, "listening", srv.Output) // Wait until listening.
+
+			cli := startWith(t, exec.Command(bincli, "--color=never", "--cleartext", "--address", addr, "search", "--name=Pierce", "--sort=phone")).Wait()
+			assert.Equal(t, 1, cli.ProcessState.ExitCode())
+			assert.Contains(t, contents(cli.Output), "unknown field 'phone'")
+		})
+
 		t.Run("should print nothing and exit with 0 when no user is found", func(t *testing.T) {
 			addr, addrMetrics := "127.0.0.1:"+freePort(), "127.0.0.1:"+freePort()
 			srv := startWith(t, exec.Command(binsrv, "--address", addr, "--address-metrics", addrMetrics, "--samples"))